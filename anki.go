@@ -0,0 +1,346 @@
+package main
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/settings_handler"
+	"github.com/klauspost/compress/zstd"
+	_ "modernc.org/sqlite"
+)
+
+// Days between reviews from which Anki calls a card mature
+const matureInterval = 21
+
+// How far along each maturity is, an item is known once it's at least as far as knownMaturity in settings.json
+var maturityRank = map[string]int{
+	settings_handler.MaturityNew:      0,
+	settings_handler.MaturityLearning: 1,
+	settings_handler.MaturityYoung:    2,
+	settings_handler.MaturityMature:   3,
+}
+
+// AnkiNote is a note from an Anki export and how far along its best card is
+type AnkiNote struct {
+	ID       string
+	GUID     string
+	Fields   []string
+	Maturity string
+	Interval int
+}
+
+var (
+	// Obsidian_to_Anki writes the id of the Anki note into the Markdown note
+	ankiIDRe   = regexp.MustCompile(`<!--ID: (\d+)-->`)
+	ankiGUIDRe = regexp.MustCompile(`^GUID: (\S+)`)
+)
+
+// Collection files in an .apkg or .colpkg, newest schema first
+// collection.anki21b is zstd compressed, exports made for new Anki versions only have a placeholder in the others
+var collectionNames = []string{"collection.anki21b", "collection.anki21", "collection.anki2"}
+
+// CardMaturity works out a card's maturity from its type and interval
+// type is 0 new, 1 learning, 2 review, 3 relearning, the interval is in days once a card is in review
+func cardMaturity(cardType, interval int) string {
+	switch {
+	case cardType == 0:
+		return settings_handler.MaturityNew
+	case cardType == 2 && interval >= matureInterval:
+		return settings_handler.MaturityMature
+	case cardType == 2:
+		return settings_handler.MaturityYoung
+	default:
+		return settings_handler.MaturityLearning
+	}
+}
+
+// ReadAnkiPackage reads the notes of an .apkg or .colpkg and the maturity of each note's best card
+func readAnkiPackage(path string) ([]AnkiNote, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	var collection *zip.File
+	for _, name := range collectionNames {
+		for _, f := range archive.File {
+			if f.Name == name {
+				collection = f
+				break
+			}
+		}
+		if collection != nil {
+			break
+		}
+	}
+	if collection == nil {
+		return nil, fmt.Errorf("no Anki collection in %s", path)
+	}
+
+	// SQLite wants a file on disk
+	tmp, err := os.CreateTemp("", "collection-*.anki2")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	rc, err := collection.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	var r io.Reader = rc
+	if collection.Name == "collection.anki21b" {
+		decoder, err := zstd.NewReader(rc)
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		r = decoder
+	}
+	if _, err := io.Copy(tmp, r); err != nil {
+		return nil, err
+	}
+	tmp.Close()
+
+	return readAnkiCollection(tmp.Name())
+}
+
+// ReadAnkiCollection reads notes and card scheduling out of an Anki collection database
+func readAnkiCollection(path string) ([]AnkiNote, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id, guid, flds FROM notes")
+	if err != nil {
+		return nil, err
+	}
+	notes := map[int64]*AnkiNote{}
+	var order []int64
+	for rows.Next() {
+		var id int64
+		var guid, fields string
+		if err := rows.Scan(&id, &guid, &fields); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		notes[id] = &AnkiNote{
+			ID:     strconv.FormatInt(id, 10),
+			GUID:   guid,
+			Fields: strings.Split(fields, "\x1f"),
+		}
+		order = append(order, id)
+	}
+	rows.Close()
+
+	rows, err = db.Query("SELECT nid, type, ivl FROM cards")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var nid int64
+		var cardType, interval int
+		if err := rows.Scan(&nid, &cardType, &interval); err != nil {
+			return nil, err
+		}
+		note, exists := notes[nid]
+		if !exists {
+			continue
+		}
+		maturity := cardMaturity(cardType, interval)
+		if note.Maturity == "" || maturityRank[maturity] > maturityRank[note.Maturity] {
+			note.Maturity = maturity
+		}
+		note.Interval = max(note.Interval, interval)
+	}
+
+	var output []AnkiNote
+	for _, id := range order {
+		output = append(output, *notes[id])
+	}
+	return output, rows.Err()
+}
+
+// ReadAnkiText reads Anki's Notes in Plain Text export, it has no scheduling so maturity is left empty
+// The GUID, note type, deck and tags columns the header lists aren't fields, so field 1 is still the note's first field
+func readAnkiText(path string) ([]AnkiNote, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	separator := '\t'
+	guidColumn := 0
+	otherColumns := map[int]bool{}
+	var body []string
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			body = append(body, line)
+			continue
+		}
+		key, value, _ := strings.Cut(strings.TrimPrefix(line, "#"), ":")
+		switch key {
+		case "separator":
+			separator = map[string]rune{"tab": '\t', "comma": ',', "semicolon": ';', "space": ' ', "pipe": '|', "colon": ':'}[value]
+			if separator == 0 {
+				separator = '\t'
+			}
+		case "guid column":
+			guidColumn, _ = strconv.Atoi(strings.TrimSpace(value))
+		case "notetype column", "deck column", "tags column":
+			if column, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				otherColumns[column] = true
+			}
+		}
+	}
+
+	reader := csv.NewReader(strings.NewReader(strings.Join(body, "\n")))
+	reader.Comma = separator
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var output []AnkiNote
+	for _, record := range records {
+		var note AnkiNote
+		for i, value := range record {
+			switch {
+			case i+1 == guidColumn:
+				note.GUID = value
+			case !otherColumns[i+1]:
+				note.Fields = append(note.Fields, value)
+			}
+		}
+		output = append(output, note)
+	}
+	return output, nil
+}
+
+// VaultItem is a word or kanji note in the vault
+type VaultItem struct {
+	Kind string
+	Name string
+}
+
+// VaultAnkiIDs finds the word, name and kanji notes that say which Anki note they became, by note id or GUID
+func vaultAnkiIDs() map[string]VaultItem {
+	output := map[string]VaultItem{}
+	index := func(dir, kind string) {
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".md" {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			item := VaultItem{Kind: kind, Name: strings.TrimSuffix(filepath.Base(path), ".md")}
+			for _, line := range strings.Split(string(data), "\n") {
+				line = strings.TrimSpace(line)
+				if match := ankiIDRe.FindStringSubmatch(line); match != nil {
+					output[match[1]] = item
+				}
+				if match := ankiGUIDRe.FindStringSubmatch(line); match != nil {
+					output[match[1]] = item
+				}
+			}
+			return nil
+		})
+	}
+	index(wordsPath, "word")
+	index(namesPath, "word")
+	index(kanjiPath, "kanji")
+	return output
+}
+
+// MatchAnkiNote finds the vault item an Anki note is about, by id or GUID first and then by the text of a field
+// Text that isn't in the vault yet is only taken as the given kind when it's a single kanji or a word JMdict has,
+// so sentence cards and other fields in a full collection don't end up known
+func matchAnkiNote(note AnkiNote, ids map[string]VaultItem, field int, kind string) (VaultItem, bool) {
+	if item, exists := ids[note.ID]; exists && note.ID != "" {
+		return item, true
+	}
+	if item, exists := ids[note.GUID]; exists && note.GUID != "" {
+		return item, true
+	}
+	if field > len(note.Fields) {
+		return VaultItem{}, false
+	}
+	text := knownItemText(note.Fields[field-1])
+	if text == "" {
+		return VaultItem{}, false
+	}
+	single := len([]rune(text)) == 1
+	if _, err := os.Stat(filepath.Join(kanjiPath, text+".md")); err == nil && single {
+		return VaultItem{Kind: "kanji", Name: text}, true
+	}
+	if _, err := os.Stat(filepath.Join(wordsPath, text+".md")); err == nil {
+		return VaultItem{Kind: "word", Name: text}, true
+	}
+	switch {
+	case kind == "kanji" && single && isKanjiChar(text):
+		return VaultItem{Kind: kind, Name: text}, true
+	case kind == "word" && len(WordLookup(text)) > 0:
+		return VaultItem{Kind: kind, Name: text}, true
+	}
+	return VaultItem{}, false
+}
+
+// ImportAnki records the maturity of every note in an Anki export against the vault's words and kanji
+func importAnki(path, kind string, field int) error {
+	var notes []AnkiNote
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".apkg", ".colpkg":
+		notes, err = readAnkiPackage(path)
+	default:
+		notes, err = readAnkiText(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	ids := vaultAnkiIDs()
+	counts := map[string]int{}
+	for _, note := range notes {
+		item, found := matchAnkiNote(note, ids, field, kind)
+		if !found {
+			continue
+		}
+		store := knownStore.Words
+		if item.Kind == "kanji" {
+			store = knownStore.Kanji
+		}
+		store[item.Name] = KnownItem{Source: KnownFromAnki, Maturity: note.Maturity, Interval: note.Interval}
+		counts[note.Maturity]++
+	}
+
+	fmt.Printf("Imported %d notes from %s\n", len(notes), path)
+	for _, maturity := range []string{settings_handler.MaturityNew, settings_handler.MaturityLearning, settings_handler.MaturityYoung, settings_handler.MaturityMature} {
+		if counts[maturity] > 0 {
+			fmt.Printf("  %s: %d\n", maturity, counts[maturity])
+		}
+	}
+	if counts[""] > 0 {
+		fmt.Printf("  no scheduling in the export: %d\n", counts[""])
+	}
+	return nil
+}
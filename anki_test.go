@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/settings_handler"
+)
+
+func TestCardMaturity(t *testing.T) {
+	tests := []struct {
+		cardType, interval int
+		want               string
+	}{
+		{0, 0, settings_handler.MaturityNew},
+		{1, 0, settings_handler.MaturityLearning},
+		{3, 30, settings_handler.MaturityLearning}, // Relearning after a lapse
+		{2, 1, settings_handler.MaturityYoung},
+		{2, 20, settings_handler.MaturityYoung},
+		{2, 21, settings_handler.MaturityMature},
+		{2, 400, settings_handler.MaturityMature},
+	}
+	for _, test := range tests {
+		if got := cardMaturity(test.cardType, test.interval); got != test.want {
+			t.Errorf("cardMaturity(%d, %d) = %s, want %s", test.cardType, test.interval, got, test.want)
+		}
+	}
+}
+
+func TestReadAnkiText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	lines := []string{
+		"#separator:tab",
+		"#html:true",
+		"#guid column:1",
+		"#notetype column:2",
+		"#deck column:3",
+		"#tags column:6",
+		"Ab1]xY\tBasic\tWords\t食べる\tto eat\tJLPT_N5",
+		"Cd2)zW\tBasic\tKanji\t食\teat",
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	notes, err := readAnkiText(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []AnkiNote{
+		{GUID: "Ab1]xY", Fields: []string{"食べる", "to eat"}},
+		{GUID: "Cd2)zW", Fields: []string{"食", "eat"}},
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("readAnkiText = %+v, want %+v", notes, want)
+	}
+}
+
+func TestMatchAnkiNote(t *testing.T) {
+	oldKanji, oldWords, oldIdx := kanjiPath, wordsPath, wordIdx
+	kanjiPath, wordsPath = t.TempDir(), t.TempDir()
+	wordIdx = map[string][]WordData{"食べる": {{Word: "食べる"}}}
+	t.Cleanup(func() { kanjiPath, wordsPath, wordIdx = oldKanji, oldWords, oldIdx })
+	for _, path := range []string{filepath.Join(kanjiPath, "猫.md"), filepath.Join(wordsPath, "猫舌.md")} {
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ids := map[string]VaultItem{
+		"1700000000000": {Kind: "word", Name: "犬"},
+		"Ab1]xY":        {Kind: "kanji", Name: "犬"},
+	}
+
+	tests := []struct {
+		name   string
+		note   AnkiNote
+		field  int
+		kind   string
+		want   VaultItem
+		wantOK bool
+	}{
+		{"note id", AnkiNote{ID: "1700000000000", Fields: []string{"anything"}}, 1, "word", VaultItem{"word", "犬"}, true},
+		{"GUID", AnkiNote{GUID: "Ab1]xY", Fields: []string{"anything"}}, 1, "word", VaultItem{"kanji", "犬"}, true},
+		{"kanji note in the vault", AnkiNote{Fields: []string{"猫, 11"}}, 1, "word", VaultItem{"kanji", "猫"}, true},
+		{"word note in the vault", AnkiNote{Fields: []string{"<b>猫舌</b>"}}, 1, "kanji", VaultItem{"word", "猫舌"}, true},
+		{"JMdict word", AnkiNote{Fields: []string{"back", "食[た]べる"}}, 2, "word", VaultItem{"word", "食べる"}, true},
+		{"new kanji", AnkiNote{Fields: []string{"鳥"}}, 1, "kanji", VaultItem{"kanji", "鳥"}, true},
+		{"kana isn't a kanji", AnkiNote{Fields: []string{"あ"}}, 1, "kanji", VaultItem{}, false},
+		{"a sentence isn't a word", AnkiNote{Fields: []string{"猫が好きです。"}}, 1, "word", VaultItem{}, false},
+		{"empty field", AnkiNote{Fields: []string{"<br>"}}, 1, "word", VaultItem{}, false},
+		{"field out of range", AnkiNote{Fields: []string{"食べる"}}, 2, "word", VaultItem{}, false},
+	}
+	for _, test := range tests {
+		got, ok := matchAnkiNote(test.note, ids, test.field, test.kind)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("%s: matchAnkiNote = %+v, %v, want %+v, %v", test.name, got, ok, test.want, test.wantOK)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import "fmt"
+
+// RunCommand handles command line use, false means there was no command and the GUI should start
+func runCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "resolve":
+		resolveCommand(args[1:])
+	case "correct":
+		correctCommand(args[1:])
+	case "known":
+		knownCommand(args[1:])
+	case "mine":
+		mineCommand(args[1:])
+	case "stats":
+		statsCommand()
+	case "review":
+		reviewCommand(args[1:])
+	case "coverage":
+		writeCoverage()
+		fmt.Printf("Wrote %s\n", coverageMd)
+	case "migrate-sentences":
+		migrateSentencesCommand()
+	default:
+		fmt.Printf("Unknown command %s\n", args[0])
+		fmt.Println("Commands:")
+		fmt.Println("  resolve <word> dictionary|userdic|ignore ...   resolve a token from Unknown.md")
+		fmt.Println("  correct <sentence> <corrected segmentation> [pos]   teach the parser a better split")
+		fmt.Println("  known add|import|anki|scan ...   mark words and kanji as known so they don't get cards")
+		fmt.Println("  mine [vault|new]   make cloze cards for sentences with just one unknown word or kanji")
+		fmt.Println("  stats   write Stats.md and Stats.json about every source in the vault")
+		fmt.Println("  review [sentences|words|kanji|names] [new cards]   study the cards in the vault without Anki")
+		fmt.Println("  coverage   write Coverage.md and a note for every JLPT level and school grade listing the kanji you have and are missing")
+		fmt.Println("  migrate-sentences   rename sentence notes from older versions and fix the links to them")
+	}
+	return true
+}
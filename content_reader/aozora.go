@@ -0,0 +1,128 @@
+package content_reader
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const aozoraRule = "-------------------------------------------------------"
+
+var (
+	aozoraHeadingRe    = regexp.MustCompile(`［＃「([^」]+)」は[大中小]見出し］`)
+	aozoraHeadingRunRe = regexp.MustCompile(`［＃[大中小]見出し］(.+?)［＃[大中小]見出し終わり］`)
+	aozoraNoteRe       = regexp.MustCompile(`※?［＃[^］]*］`)
+)
+
+// IsAozora guesses whether a text file uses Aozora Bunko markup
+func IsAozora(text string) bool {
+	return strings.Contains(text, "【テキスト中に現れる記号について】") ||
+		strings.Contains(text, "\n底本：") ||
+		(strings.Contains(text, "《") && strings.Contains(text, "［＃"))
+}
+
+// ReadAozora strips the header, footer and editorial notes of an Aozora Bunko text
+// and turns 《》 ruby into Readings, headings marked up in the text start new chapters
+func ReadAozora(lines []string) []Segment {
+	// Header is title, author, then the symbol explanation between two rules
+	start := 0
+	rules := 0
+	for i, line := range lines {
+		if strings.HasPrefix(line, aozoraRule) {
+			rules++
+			if rules == 2 {
+				start = i + 1
+				break
+			}
+		}
+	}
+	if rules < 2 {
+		start = 0
+	}
+
+	var output []Segment
+	chapter := ""
+	for _, line := range lines[start:] {
+		if strings.HasPrefix(line, "底本：") {
+			break
+		}
+		if match := aozoraHeadingRe.FindStringSubmatch(line); match != nil {
+			chapter = match[1]
+		} else if match := aozoraHeadingRunRe.FindStringSubmatch(line); match != nil {
+			chapter = stripAozoraRuby(match[1])
+		}
+
+		line = aozoraNoteRe.ReplaceAllString(line, "")
+		text, readings := parseAozoraRuby(line)
+		// paragraphs are indented with a full width space
+		text = strings.TrimSpace(strings.TrimLeft(text, "　"))
+		if text == "" {
+			continue
+		}
+		output = append(output, Segment{Text: text, Chapter: chapter, Readings: readings})
+	}
+	return output
+}
+
+// ParseAozoraRuby removes ruby markup, ｜ marks where a base starts, otherwise the base is the run of
+// the same kind of character right before 《
+func parseAozoraRuby(line string) (string, []Ruby) {
+	var readings []Ruby
+	var text []rune
+	baseStart := -1
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case '｜':
+			baseStart = len(text)
+		case '《':
+			end := i + 1
+			for end < len(runes) && runes[end] != '》' {
+				end++
+			}
+			if end == len(runes) {
+				text = append(text, r)
+				continue
+			}
+			reading := string(runes[i+1 : end])
+			start := baseStart
+			if start < 0 {
+				start = len(text)
+				for start > 0 && sameScript(text[start-1], text[len(text)-1]) {
+					start--
+				}
+			}
+			if start < len(text) {
+				readings = append(readings, Ruby{Base: string(text[start:]), Reading: reading})
+			}
+			baseStart = -1
+			i = end
+		default:
+			text = append(text, r)
+		}
+	}
+	return string(text), readings
+}
+
+func stripAozoraRuby(s string) string {
+	text, _ := parseAozoraRuby(s)
+	return text
+}
+
+func isKanji(r rune) bool {
+	return unicode.Is(unicode.Han, r) || r == '々' || r == '〆' || r == 'ヶ'
+}
+
+func sameScript(a, b rune) bool {
+	switch {
+	case isKanji(b):
+		return isKanji(a)
+	case unicode.Is(unicode.Hiragana, b):
+		return unicode.Is(unicode.Hiragana, a)
+	case unicode.Is(unicode.Katakana, b):
+		return unicode.Is(unicode.Katakana, a) || a == 'ー'
+	default:
+		return unicode.IsLetter(a) && !isKanji(a) && !unicode.Is(unicode.Hiragana, a) && !unicode.Is(unicode.Katakana, a)
+	}
+}
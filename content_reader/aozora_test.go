@@ -0,0 +1,32 @@
+package content_reader
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAozoraRuby(t *testing.T) {
+	tests := []struct {
+		line     string
+		text     string
+		readings []Ruby
+	}{
+		{"吾輩《わがはい》は猫である。", "吾輩は猫である。", []Ruby{{"吾輩", "わがはい"}}},
+		// Only the kanji right before 《 are the base
+		{"この小説《しょうせつ》", "この小説", []Ruby{{"小説", "しょうせつ"}}},
+		{"｜東京駅《とうきょうえき》に着く", "東京駅に着く", []Ruby{{"東京駅", "とうきょうえき"}}},
+		// ｜ can take in kana the run wouldn't
+		{"｜お茶漬け《おちゃづけ》", "お茶漬け", []Ruby{{"お茶漬け", "おちゃづけ"}}},
+		{"カタカナ《かたかな》", "カタカナ", []Ruby{{"カタカナ", "かたかな"}}},
+		{"山《やま》と川《かわ》", "山と川", []Ruby{{"山", "やま"}, {"川", "かわ"}}},
+		// An unclosed 《 is just text
+		{"開き《だけ", "開き《だけ", nil},
+		{"ルビなし", "ルビなし", nil},
+	}
+	for _, test := range tests {
+		text, readings := parseAozoraRuby(test.line)
+		if text != test.text || !reflect.DeepEqual(readings, test.readings) {
+			t.Errorf("parseAozoraRuby(%q) = %q, %v, want %q, %v", test.line, text, readings, test.text, test.readings)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package content_reader
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Segment is a piece of a source that sentences get cut from, along with where in the source it was
+type Segment struct {
+	Text     string
+	Start    string // Start and End are cue timestamps, empty for plain text
+	End      string
+	Chapter  string
+	Readings []Ruby // Readings the source itself gives, in the order they appear in Text
+	Lyric    bool   // Lyric lines are one sentence each, however they're punctuated
+	Page     int    // Page and Block place a manga text block, zero for everything else
+	Block    int
+}
+
+// Ruby is a reading written over a run of text in the source
+type Ruby struct {
+	Base    string
+	Reading string
+}
+
+// Extensions that have a reader, a .json file is only read when IsMokuroPage says it's a mokuro page
+var Extensions = []string{".txt", ".srt", ".vtt", ".ass", ".ssa", ".html", ".htm", ".xhtml", ".epub", ".lrc", ".mokuro"}
+
+var (
+	htmlTagRe = regexp.MustCompile(`<[^>]*>`)
+	rubyRtRe  = regexp.MustCompile(`(?s)<rt[^>]*>.*?</rt>|<rp[^>]*>.*?</rp>`)
+)
+
+// Read picks a reader from the file extension, anything unknown is treated as plain text
+func Read(path string, data []byte) ([]Segment, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".epub":
+		return ReadEPUB(data)
+	case ".mokuro", ".json":
+		return ReadMokuro(data)
+	}
+
+	text, _, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm", ".xhtml":
+		return ReadHTML(strings.NewReader(text), ""), nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".srt":
+		return ReadSRT(lines), nil
+	case ".vtt":
+		return ReadVTT(lines), nil
+	case ".ass", ".ssa":
+		return ReadASS(lines), nil
+	case ".lrc":
+		return ReadLRC(lines), nil
+	default:
+		if IsAozora(text) {
+			return ReadAozora(lines), nil
+		}
+		return ReadText(lines), nil
+	}
+}
+
+// ReadText makes every line of a plain text file its own segment
+func ReadText(lines []string) []Segment {
+	var output []Segment
+	for _, line := range lines {
+		output = append(output, Segment{Text: line})
+	}
+	return output
+}
+
+// StripTags removes markup, ruby readings go with it so they don't end up in the text
+func StripTags(s string) string {
+	s = rubyRtRe.ReplaceAllString(s, "")
+	return htmlTagRe.ReplaceAllString(s, "")
+}
@@ -0,0 +1,113 @@
+package content_reader
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	textunicode "golang.org/x/text/encoding/unicode"
+)
+
+// Encodings tried on files without a BOM that aren't UTF-8
+var candidateEncodings = []struct {
+	Name     string
+	Encoding encoding.Encoding
+}{
+	{"Shift_JIS", japanese.ShiftJIS},
+	{"EUC-JP", japanese.EUCJP},
+}
+
+// Decode detects a file's encoding from its BOM or its bytes and returns it as UTF-8 text,
+// the error says the encoding couldn't be determined and the text would just be junk
+func Decode(data []byte) (string, string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(data[3:]), "UTF-8", nil
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeWith(textunicode.UTF16(textunicode.LittleEndian, textunicode.ExpectBOM), data, "UTF-16LE")
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeWith(textunicode.UTF16(textunicode.BigEndian, textunicode.ExpectBOM), data, "UTF-16BE")
+	case bytes.Contains(data, []byte{0x1B, '$', 'B'}) || bytes.Contains(data, []byte{0x1B, '$', '@'}):
+		return decodeWith(japanese.ISO2022JP, data, "ISO-2022-JP")
+	}
+
+	if utf16Order := guessUTF16(data); utf16Order != "" {
+		if utf16Order == "UTF-16LE" {
+			return decodeWith(textunicode.UTF16(textunicode.LittleEndian, textunicode.IgnoreBOM), data, utf16Order)
+		}
+		return decodeWith(textunicode.UTF16(textunicode.BigEndian, textunicode.IgnoreBOM), data, utf16Order)
+	}
+	if utf8.Valid(data) {
+		return string(data), "UTF-8", nil
+	}
+
+	// Both legacy encodings will decode almost anything, the right one decodes cleanly into kana
+	best, bestName, bestScore := "", "", 0
+	for _, candidate := range candidateEncodings {
+		decoded, err := candidate.Encoding.NewDecoder().Bytes(data)
+		if err != nil || bytes.ContainsRune(decoded, utf8.RuneError) {
+			continue
+		}
+		if score := kanaCount(string(decoded)); score > bestScore {
+			best, bestName, bestScore = string(decoded), candidate.Name, score
+		}
+	}
+	if bestName == "" {
+		return "", "", fmt.Errorf("could not determine the encoding, it isn't UTF-8, UTF-16, Shift_JIS or EUC-JP")
+	}
+	return best, bestName, nil
+}
+
+func decodeWith(e encoding.Encoding, data []byte, name string) (string, string, error) {
+	decoded, err := e.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", "", fmt.Errorf("could not decode as %s: %v", name, err)
+	}
+	return strings.TrimPrefix(string(decoded), "\uFEFF"), name, nil
+}
+
+// GuessUTF16 spots BOM-less UTF-16, read the wrong way round Japanese text turns into unprintable
+// runes and has next to no kana, read the right way round it is full of kana
+func guessUTF16(data []byte) string {
+	if len(data) < 4 || len(data)%2 != 0 {
+		return ""
+	}
+	orders := []struct {
+		Name       string
+		Endianness textunicode.Endianness
+	}{
+		{"UTF-16LE", textunicode.LittleEndian},
+		{"UTF-16BE", textunicode.BigEndian},
+	}
+	for _, order := range orders {
+		decoded, err := textunicode.UTF16(order.Endianness, textunicode.IgnoreBOM).NewDecoder().Bytes(data)
+		if err != nil {
+			continue
+		}
+		runes, bad := 0, 0
+		for _, r := range string(decoded) {
+			runes++
+			if r == utf8.RuneError || unicode.Is(unicode.Co, r) || (!unicode.IsPrint(r) && !unicode.IsSpace(r)) {
+				bad++
+			}
+		}
+		if kanaCount(string(decoded))*10 >= runes && bad*20 < runes {
+			return order.Name
+		}
+	}
+	return ""
+}
+
+func kanaCount(s string) int {
+	count := 0
+	for _, r := range s {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana) || r == '。' || r == '、' {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,54 @@
+package content_reader
+
+import (
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	textunicode "golang.org/x/text/encoding/unicode"
+)
+
+const encodingSample = "吾輩は猫である。名前はまだ無い。どこで生れたかとんと見当がつかぬ。"
+
+func encode(t *testing.T, e encoding.Encoding, s string) []byte {
+	t.Helper()
+	data, err := e.NewEncoder().Bytes([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		encoding string
+	}{
+		{"UTF-8", []byte(encodingSample), "UTF-8"},
+		{"UTF-8 BOM", append([]byte{0xEF, 0xBB, 0xBF}, encodingSample...), "UTF-8"},
+		{"UTF-16LE BOM", encode(t, textunicode.UTF16(textunicode.LittleEndian, textunicode.UseBOM), encodingSample), "UTF-16LE"},
+		{"UTF-16BE BOM", encode(t, textunicode.UTF16(textunicode.BigEndian, textunicode.UseBOM), encodingSample), "UTF-16BE"},
+		{"UTF-16LE", encode(t, textunicode.UTF16(textunicode.LittleEndian, textunicode.IgnoreBOM), encodingSample), "UTF-16LE"},
+		{"UTF-16BE", encode(t, textunicode.UTF16(textunicode.BigEndian, textunicode.IgnoreBOM), encodingSample), "UTF-16BE"},
+		{"Shift_JIS", encode(t, japanese.ShiftJIS, encodingSample), "Shift_JIS"},
+		{"EUC-JP", encode(t, japanese.EUCJP, encodingSample), "EUC-JP"},
+		{"ISO-2022-JP", encode(t, japanese.ISO2022JP, encodingSample), "ISO-2022-JP"},
+	}
+	for _, test := range tests {
+		text, name, err := Decode(test.data)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if name != test.encoding || text != encodingSample {
+			t.Errorf("%s: decoded as %s to %q", test.name, name, text)
+		}
+	}
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	if _, _, err := Decode([]byte{0xFF, 0x80, 0x81, 0xFF, 0x80}); err == nil {
+		t.Error("junk bytes decoded without an error")
+	}
+}
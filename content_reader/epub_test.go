@@ -0,0 +1,67 @@
+package content_reader
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// zipFiles builds an archive in memory, files in the order given
+func zipFiles(t *testing.T, files [][2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, file := range files {
+		f, err := w.Create(file[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(file[1]))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadEPUB(t *testing.T) {
+	data := zipFiles(t, [][2]string{
+		{"mimetype", "application/epub+zip"},
+		{"META-INF/container.xml", `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`},
+		{"OEBPS/content.opf", `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <manifest>
+    <item id="first" href="text/%E7%AC%AC%E4%B8%80%E7%AB%A0.xhtml" media-type="application/xhtml+xml"/>
+    <item id="second" href="text/two.xhtml" media-type="application/xhtml+xml"/>
+    <item id="gone" href="text/missing.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine><itemref idref="second"/><itemref idref="nothing"/><itemref idref="first"/><itemref idref="gone"/></spine>
+</package>`},
+		// Stored in a different order than the spine reads them
+		{"OEBPS/text/第一章.xhtml", `<html><body><p><ruby>猫<rt>ねこ</rt></ruby>です。</p></body></html>`},
+		{"OEBPS/text/two.xhtml", `<html><body><p>前書き</p></body></html>`},
+	})
+
+	got, err := ReadEPUB(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Segment{
+		{Text: "前書き", Chapter: "Chapter 1"},
+		{Text: "猫です。", Readings: []Ruby{{Base: "猫", Reading: "ねこ"}}, Chapter: "Chapter 2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadEPUB = %+v, want %+v", got, want)
+	}
+
+	if _, err := ReadEPUB(zipFiles(t, [][2]string{{"mimetype", "application/epub+zip"}})); err == nil {
+		t.Error("an epub with no container.xml read without an error")
+	}
+	if _, err := ReadEPUB([]byte("not a zip")); err == nil {
+		t.Error("a file that isn't a zip read without an error")
+	}
+}
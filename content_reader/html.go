@@ -0,0 +1,128 @@
+package content_reader
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Elements that end a line of text
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Br: true, atom.Li: true, atom.Tr: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Blockquote: true, atom.Section: true, atom.Article: true,
+}
+
+// Elements whose text is never part of the content
+var skippedElements = map[atom.Atom]bool{
+	atom.Head: true, atom.Script: true, atom.Style: true, atom.Rp: true, atom.Nav: true,
+}
+
+// ReadHTML extracts body text one line per block, ruby readings are kept as Readings instead of text
+// The chapter is the first heading, then the page title, then the one passed in
+func ReadHTML(r io.Reader, chapter string) []Segment {
+	var output []Segment
+	var line strings.Builder
+	var readings []Ruby
+	var rubyBase, rubyReading strings.Builder
+	title := ""
+	heading := ""
+	inHeading := false
+	inRuby := false
+	inRt := false
+	skipDepth := 0
+
+	flush := func() {
+		text := strings.TrimSpace(line.String())
+		if text != "" {
+			output = append(output, Segment{Text: text, Readings: readings})
+		}
+		line.Reset()
+		readings = nil
+	}
+	closeRuby := func() {
+		if rubyBase.Len() > 0 && rubyReading.Len() > 0 {
+			readings = append(readings, Ruby{Base: rubyBase.String(), Reading: rubyReading.String()})
+		}
+		rubyBase.Reset()
+		rubyReading.Reset()
+	}
+
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		token := z.Token()
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if skippedElements[token.DataAtom] && tt == html.StartTagToken {
+				skipDepth++
+			}
+			if token.DataAtom == atom.Title && tt == html.StartTagToken {
+				z.Next()
+				title = strings.TrimSpace(string(z.Text()))
+			}
+			if blockElements[token.DataAtom] {
+				flush()
+			}
+			switch token.DataAtom {
+			case atom.Ruby:
+				inRuby = true
+			case atom.Rt:
+				inRt = true
+			case atom.H1, atom.H2, atom.H3:
+				inHeading = heading == ""
+			}
+		case html.EndTagToken:
+			if skippedElements[token.DataAtom] && skipDepth > 0 {
+				skipDepth--
+			}
+			if blockElements[token.DataAtom] {
+				flush()
+			}
+			switch token.DataAtom {
+			case atom.Ruby:
+				closeRuby()
+				inRuby = false
+			case atom.Rt:
+				inRt = false
+				// Several base/rt pairs can share one ruby element
+				closeRuby()
+			case atom.H1, atom.H2, atom.H3:
+				inHeading = false
+			}
+		case html.TextToken:
+			if skipDepth > 0 {
+				continue
+			}
+			text := strings.TrimSpace(token.Data)
+			if inRt {
+				rubyReading.WriteString(text)
+				continue
+			}
+			if inRuby {
+				rubyBase.WriteString(text)
+			}
+			if inHeading {
+				heading += text
+			}
+			line.WriteString(text)
+		}
+	}
+	flush()
+
+	switch {
+	case heading != "":
+		chapter = heading
+	case title != "":
+		chapter = title
+	}
+	for i := range output {
+		output[i].Chapter = chapter
+	}
+	return output
+}
@@ -0,0 +1,59 @@
+package content_reader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []Segment
+	}{
+		{
+			"ruby with rp",
+			`<p><ruby>漢字<rp>(</rp><rt>かんじ</rt><rp>)</rp></ruby>を読む</p>`,
+			[]Segment{{Text: "漢字を読む", Readings: []Ruby{{Base: "漢字", Reading: "かんじ"}}, Chapter: "Chapter 1"}},
+		},
+		{
+			"several base and rt pairs in one ruby",
+			`<p><ruby>東<rt>とう</rt>京<rt>きょう</rt></ruby>へ</p>`,
+			[]Segment{{Text: "東京へ", Readings: []Ruby{{Base: "東", Reading: "とう"}, {Base: "京", Reading: "きょう"}}, Chapter: "Chapter 1"}},
+		},
+		{
+			"ruby with no reading",
+			`<p><ruby>猫<rt></rt></ruby></p>`,
+			[]Segment{{Text: "猫", Chapter: "Chapter 1"}},
+		},
+		{
+			"readings stay with their line",
+			`<p><ruby>一<rt>いち</rt></ruby></p><p>二<br>三</p>`,
+			[]Segment{
+				{Text: "一", Readings: []Ruby{{Base: "一", Reading: "いち"}}, Chapter: "Chapter 1"},
+				{Text: "二", Chapter: "Chapter 1"},
+				{Text: "三", Chapter: "Chapter 1"},
+			},
+		},
+		{
+			"the first heading names the chapter, over the title",
+			`<html><head><title>題名</title><style>p{}</style></head><body><nav>目次</nav><h1>第一章</h1><h2>節</h2><p>本文</p><script>x()</script></body></html>`,
+			[]Segment{
+				{Text: "第一章", Chapter: "第一章"},
+				{Text: "節", Chapter: "第一章"},
+				{Text: "本文", Chapter: "第一章"},
+			},
+		},
+		{
+			"the title when there's no heading",
+			`<html><head><title> 題名 </title></head><body><div>本文</div></body></html>`,
+			[]Segment{{Text: "本文", Chapter: "題名"}},
+		},
+	}
+	for _, test := range tests {
+		if got := ReadHTML(strings.NewReader(test.html), "Chapter 1"); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: ReadHTML = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
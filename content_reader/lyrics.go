@@ -0,0 +1,92 @@
+package content_reader
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	lrcTimeRe   = regexp.MustCompile(`^\[(\d{1,3}):(\d{2})(?:[.:](\d{1,3}))?\]`)
+	lrcTagRe    = regexp.MustCompile(`^\[([a-zA-Z#]+):(.*)\]\s*$`)
+	lrcWordTime = regexp.MustCompile(`<\d{1,3}:\d{2}(?:[.:]\d{1,3})?>`)
+)
+
+type lrcLine struct {
+	Millis int
+	Text   string
+}
+
+// ReadLRC reads timed lyrics, every line is its own segment and ends where the next line starts
+// A line sung more than once can carry several timestamps, it gets a segment for each of them
+func ReadLRC(lines []string) []Segment {
+	var timed []lrcLine
+	offset := 0
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if match := lrcTagRe.FindStringSubmatch(line); match != nil {
+			// ti, ar, al, by, length... only the offset changes how the file is read
+			if strings.EqualFold(match[1], "offset") {
+				offset, _ = strconv.Atoi(strings.TrimSpace(match[2]))
+			}
+			continue
+		}
+
+		var times []int
+		for {
+			match := lrcTimeRe.FindStringSubmatch(line)
+			if match == nil {
+				break
+			}
+			times = append(times, lrcMillis(match[1], match[2], match[3]))
+			line = line[len(match[0]):]
+		}
+		text := strings.TrimSpace(lrcWordTime.ReplaceAllString(line, ""))
+		for _, t := range times {
+			// Empty lines are kept for now, they mark where the line before them ends
+			timed = append(timed, lrcLine{Millis: t, Text: text})
+		}
+	}
+
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].Millis < timed[j].Millis })
+
+	var output []Segment
+	for i, l := range timed {
+		if l.Text == "" {
+			continue
+		}
+		segment := Segment{
+			Text:  l.Text,
+			Start: lrcTimestamp(l.Millis - offset),
+			Lyric: true,
+		}
+		if i+1 < len(timed) {
+			segment.End = lrcTimestamp(timed[i+1].Millis - offset)
+		}
+		output = append(output, segment)
+	}
+	return output
+}
+
+// LrcMillis turns the minutes, seconds and fraction of an LRC timestamp into milliseconds
+func lrcMillis(minutes, seconds, fraction string) int {
+	m, _ := strconv.Atoi(minutes)
+	s, _ := strconv.Atoi(seconds)
+	f := 0
+	if fraction != "" {
+		// .5, .50 and .500 are all half a second
+		f, _ = strconv.Atoi((fraction + "00")[:3])
+	}
+	return (m*60+s)*1000 + f
+}
+
+// LrcTimestamp writes milliseconds the way subtitle cue times are written
+func lrcTimestamp(millis int) string {
+	if millis < 0 {
+		millis = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", millis/3600000, millis/60000%60, millis/1000%60, millis%1000)
+}
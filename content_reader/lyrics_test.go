@@ -0,0 +1,55 @@
+package content_reader
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadLRC(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []Segment
+	}{
+		{
+			"in order",
+			[]string{"[ti:歌]", "[00:01.00]一行目", "[00:05.50]二行目"},
+			[]Segment{
+				{Text: "一行目", Start: "00:00:01.000", End: "00:00:05.500", Lyric: true},
+				{Text: "二行目", Start: "00:00:05.500", Lyric: true},
+			},
+		},
+		{
+			"repeated line",
+			[]string{"[00:01.00][00:10.00]サビ", "[00:05.00]間"},
+			[]Segment{
+				{Text: "サビ", Start: "00:00:01.000", End: "00:00:05.000", Lyric: true},
+				{Text: "間", Start: "00:00:05.000", End: "00:00:10.000", Lyric: true},
+				{Text: "サビ", Start: "00:00:10.000", Lyric: true},
+			},
+		},
+		{
+			"an empty line ends the one before",
+			[]string{"[00:01.00]歌", "[00:04.00]", "[00:09.00]次"},
+			[]Segment{
+				{Text: "歌", Start: "00:00:01.000", End: "00:00:04.000", Lyric: true},
+				{Text: "次", Start: "00:00:09.000", Lyric: true},
+			},
+		},
+		{
+			"offset and word times",
+			[]string{"[offset:500]", "[00:02.5]<00:02.50>一<00:03.00>二"},
+			[]Segment{{Text: "一二", Start: "00:00:02.000", Lyric: true}},
+		},
+		{
+			"colon fraction and three digit minutes",
+			[]string{"[100:00:25]長い"},
+			[]Segment{{Text: "長い", Start: "01:40:00.250", Lyric: true}},
+		},
+	}
+	for _, test := range tests {
+		if got := ReadLRC(test.lines); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: ReadLRC = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package content_reader
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MetaSuffix names a source's sidecar file: Episode 01.srt is described by Episode 01.meta.json
+const MetaSuffix = ".meta.json"
+
+// Meta is what a sidecar file says about a source
+type Meta struct {
+	Title   string   `json:"title"`
+	Author  string   `json:"author"`
+	Series  string   `json:"series"`
+	Episode string   `json:"episode"`
+	URL     string   `json:"url"`
+	Level   string   `json:"level"`
+	Tags    []string `json:"tags"`
+}
+
+// MetaPath is where the sidecar file for a source file or folder would be
+func MetaPath(source string) string {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return source + MetaSuffix
+	}
+	return strings.TrimSuffix(source, filepath.Ext(source)) + MetaSuffix
+}
+
+// ReadMeta reads a source's sidecar file, a source without one gets an empty Meta
+func ReadMeta(source string) (Meta, error) {
+	var meta Meta
+	data, err := os.ReadFile(MetaPath(source))
+	if os.IsNotExist(err) {
+		return meta, nil
+	}
+	if err != nil {
+		return meta, err
+	}
+	err = json.Unmarshal(data, &meta)
+	return meta, err
+}
+
+// IsMeta reports whether a file is a sidecar rather than a source
+func IsMeta(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), MetaSuffix)
+}
@@ -0,0 +1,176 @@
+package content_reader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mokuroBlock is one text block, usually a speech bubble, with the OCR'd lines in reading order
+type mokuroBlock struct {
+	Box      []float64 `json:"box"`
+	Vertical bool      `json:"vertical"`
+	Lines    []string  `json:"lines"`
+}
+
+type mokuroPage struct {
+	ImgPath string        `json:"img_path"`
+	Blocks  []mokuroBlock `json:"blocks"`
+}
+
+// mokuroVolume is a whole .mokuro file, older versions write one page per .json file instead
+type mokuroVolume struct {
+	Title  string       `json:"title"`
+	Volume string       `json:"volume"`
+	Pages  []mokuroPage `json:"pages"`
+}
+
+var pageNumberRe = regexp.MustCompile(`\d+`)
+
+// ReadMokuro reads a .mokuro volume or a single page's .json, each text block is a segment
+func ReadMokuro(data []byte) ([]Segment, error) {
+	var volume mokuroVolume
+	if err := json.Unmarshal(data, &volume); err != nil {
+		return nil, err
+	}
+	if len(volume.Pages) > 0 {
+		var output []Segment
+		for i, page := range volume.Pages {
+			output = append(output, mokuroPageSegments(page, i+1)...)
+		}
+		return output, nil
+	}
+
+	var page mokuroPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, err
+	}
+	if page.Blocks == nil {
+		return nil, fmt.Errorf("not mokuro output, there are no pages or text blocks")
+	}
+	return mokuroPageSegments(page, 1), nil
+}
+
+// ReadMokuroDir reads a folder of per-page .json files as one volume, pages go in file name order
+func ReadMokuroDir(dir string) ([]Segment, error) {
+	files, err := pageFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	// 2.json comes before 10.json
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := pageNumber(files[i]), pageNumber(files[j])
+		if a != b {
+			return a < b
+		}
+		return files[i] < files[j]
+	})
+
+	var output []Segment
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var page mokuroPage
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		if page.ImgPath == "" {
+			page.ImgPath = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+		output = append(output, mokuroPageSegments(page, i+1)...)
+	}
+	return output, nil
+}
+
+// IsMokuroPage reports whether a .json file is a page of mokuro output, one with a blocks array
+func IsMokuroPage(path string) bool {
+	if strings.ToLower(filepath.Ext(path)) != ".json" || IsMeta(path) {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	blocks := bytes.TrimSpace(fields["blocks"])
+	return len(blocks) > 0 && blocks[0] == '['
+}
+
+// IsMokuroDir reports whether a folder holds per-page OCR output, page .json files and no other kind of source
+func IsMokuroDir(dir string) bool {
+	files, err := pageFiles(dir)
+	if err != nil || len(files) == 0 {
+		return false
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && ext != ".json" && slices.Contains(Extensions, ext) {
+			return false
+		}
+	}
+	return true
+}
+
+// PageFiles lists the page .json files in a folder, leaving out sidecars and any other JSON
+func pageFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var output []string
+	for _, file := range files {
+		if IsMokuroPage(file) {
+			output = append(output, file)
+		}
+	}
+	return output, nil
+}
+
+// MokuroPageSegments makes a segment of every block on a page, the page is the chapter so the Content note is split up by page
+func mokuroPageSegments(page mokuroPage, number int) []Segment {
+	chapter := fmt.Sprintf("Page %d", number)
+	if page.ImgPath != "" {
+		chapter += fmt.Sprintf(" (%s)", filepath.Base(page.ImgPath))
+	}
+
+	var output []Segment
+	for i, block := range page.Blocks {
+		// Lines are just where the bubble wraps, the block reads as one piece of text
+		text := strings.TrimSpace(strings.Join(block.Lines, ""))
+		if text == "" {
+			continue
+		}
+		output = append(output, Segment{
+			Text:    text,
+			Chapter: chapter,
+			Page:    number,
+			Block:   i + 1,
+		})
+	}
+	return output
+}
+
+func pageNumber(file string) int {
+	matches := pageNumberRe.FindAllString(filepath.Base(file), -1)
+	if len(matches) == 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(matches[len(matches)-1])
+	return n
+}
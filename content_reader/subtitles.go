@@ -0,0 +1,163 @@
+package content_reader
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	srtCueNumRe  = regexp.MustCompile(`^\s*\d+\s*$`)
+	srtTimingRe  = regexp.MustCompile(`^\s*(\d{1,2}:\d{2}:\d{2}[,.]\d{1,3})\s*-->\s*(\d{1,2}:\d{2}:\d{2}[,.]\d{1,3})`)
+	vttTimingRe  = regexp.MustCompile(`^\s*((?:\d+:)?\d{2}:\d{2}\.\d{3})\s*-->\s*((?:\d+:)?\d{2}:\d{2}\.\d{3})`)
+	assOverrides = regexp.MustCompile(`\{[^}]*\}`)
+)
+
+// ReadSRT reads SubRip cues, cue numbers and timing lines are dropped and each cue keeps its start and end
+func ReadSRT(lines []string) []Segment {
+	var output []Segment
+	var current *Segment
+	var text []string
+	flush := func() {
+		if current != nil && len(text) > 0 {
+			current.Text = strings.Join(text, "\n")
+			output = append(output, *current)
+		}
+		current = nil
+		text = nil
+	}
+
+	for i, line := range lines {
+		if match := srtTimingRe.FindStringSubmatch(line); match != nil {
+			flush()
+			current = &Segment{
+				Start: strings.ReplaceAll(match[1], ",", "."),
+				End:   strings.ReplaceAll(match[2], ",", "."),
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		if current == nil {
+			// cue number
+			continue
+		}
+		// Some files leave out the blank line before the next cue's number
+		if srtCueNumRe.MatchString(line) && i+1 < len(lines) && srtTimingRe.MatchString(lines[i+1]) {
+			continue
+		}
+		text = append(text, stripSubtitleTags(line))
+	}
+	flush()
+	return output
+}
+
+// ReadVTT reads WebVTT cues, skipping the header, NOTE, STYLE and REGION blocks
+func ReadVTT(lines []string) []Segment {
+	var output []Segment
+	var current *Segment
+	var text []string
+	skipping := false
+	flush := func() {
+		if current != nil && len(text) > 0 {
+			current.Text = strings.Join(text, "\n")
+			output = append(output, *current)
+		}
+		current = nil
+		text = nil
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			flush()
+			skipping = false
+			continue
+		}
+		if skipping {
+			continue
+		}
+		if current == nil && (strings.HasPrefix(trimmed, "WEBVTT") || strings.HasPrefix(trimmed, "NOTE") ||
+			strings.HasPrefix(trimmed, "STYLE") || strings.HasPrefix(trimmed, "REGION")) {
+			skipping = true
+			continue
+		}
+		if match := vttTimingRe.FindStringSubmatch(line); match != nil {
+			flush()
+			current = &Segment{Start: match[1], End: match[2]}
+			continue
+		}
+		if current == nil {
+			// cue identifier
+			continue
+		}
+		text = append(text, stripSubtitleTags(line))
+	}
+	flush()
+	return output
+}
+
+// ReadASS reads the Dialogue lines of an ASS/SSA [Events] section using its Format line
+func ReadASS(lines []string) []Segment {
+	var output []Segment
+	inEvents := false
+	format := []string{"layer", "start", "end", "style", "name", "marginl", "marginr", "marginv", "effect", "text"}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inEvents = strings.EqualFold(trimmed, "[Events]")
+			continue
+		}
+		if !inEvents {
+			continue
+		}
+
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			continue
+		}
+		switch key {
+		case "Format":
+			format = nil
+			for _, f := range strings.Split(value, ",") {
+				format = append(format, strings.ToLower(strings.TrimSpace(f)))
+			}
+		case "Dialogue":
+			// Text is always last and may itself contain commas
+			fields := strings.SplitN(strings.TrimSpace(value), ",", len(format))
+			if len(fields) != len(format) {
+				continue
+			}
+			segment := Segment{}
+			for i, f := range format {
+				switch f {
+				case "start":
+					segment.Start = fields[i]
+				case "end":
+					segment.End = fields[i]
+				case "text":
+					segment.Text = stripASSTags(fields[i])
+				}
+			}
+			if strings.TrimSpace(segment.Text) != "" {
+				output = append(output, segment)
+			}
+		}
+	}
+	return output
+}
+
+func stripSubtitleTags(s string) string {
+	s = assOverrides.ReplaceAllString(s, "")
+	return StripTags(s)
+}
+
+func stripASSTags(s string) string {
+	s = assOverrides.ReplaceAllString(s, "")
+	s = strings.ReplaceAll(s, `\N`, "\n")
+	s = strings.ReplaceAll(s, `\n`, "\n")
+	s = strings.ReplaceAll(s, `\h`, " ")
+	return s
+}
@@ -0,0 +1,147 @@
+package content_reader
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadSRT(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []Segment
+	}{
+		{
+			"cues",
+			[]string{
+				"1",
+				"00:00:01,000 --> 00:00:02,500",
+				"こんにちは",
+				"",
+				"2",
+				"00:00:03,000 --> 00:00:04,000 X1:40 X2:600",
+				"<i>一行目</i>",
+				"{\\an8}二行目",
+			},
+			[]Segment{
+				{Text: "こんにちは", Start: "00:00:01.000", End: "00:00:02.500"},
+				{Text: "一行目\n二行目", Start: "00:00:03.000", End: "00:00:04.000"},
+			},
+		},
+		{
+			"no blank line between cues, single digit hours and dots",
+			[]string{
+				"1",
+				"0:00:01.000 --> 0:00:02.000",
+				"一",
+				"2",
+				"0:00:02.000 --> 0:00:03.000",
+				"二",
+			},
+			[]Segment{
+				{Text: "一", Start: "0:00:01.000", End: "0:00:02.000"},
+				{Text: "二", Start: "0:00:02.000", End: "0:00:03.000"},
+			},
+		},
+		{
+			"a cue with no text",
+			[]string{"1", "00:00:01,000 --> 00:00:02,000", "", "2", "00:00:03,000 --> 00:00:04,000", "　二"},
+			[]Segment{{Text: "　二", Start: "00:00:03.000", End: "00:00:04.000"}},
+		},
+		{"empty", nil, nil},
+	}
+	for _, test := range tests {
+		if got := ReadSRT(test.lines); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: ReadSRT = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestReadVTT(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []Segment
+	}{
+		{
+			"header, blocks and cue settings",
+			[]string{
+				"WEBVTT - 字幕",
+				"Kind: captions",
+				"",
+				"STYLE",
+				"::cue { color: yellow }",
+				"",
+				"NOTE これは飛ばす",
+				"00:00:00.000 --> 00:00:01.000",
+				"",
+				"REGION",
+				"id:top",
+				"",
+				"intro",
+				"00:01.000 --> 00:02.500 align:start position:10%",
+				"<v 太郎>おはよう</v>",
+				"<c.yellow>ございます</c>",
+				"",
+				"01:00:03.000 --> 01:00:04.000",
+				"二",
+			},
+			[]Segment{
+				{Text: "おはよう\nございます", Start: "00:01.000", End: "00:02.500"},
+				{Text: "二", Start: "01:00:03.000", End: "01:00:04.000"},
+			},
+		},
+		{
+			"a cue's text can start with NOTE",
+			[]string{"WEBVTT", "", "00:00:01.000 --> 00:00:02.000", "NOTEの話"},
+			[]Segment{{Text: "NOTEの話", Start: "00:00:01.000", End: "00:00:02.000"}},
+		},
+	}
+	for _, test := range tests {
+		if got := ReadVTT(test.lines); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: ReadVTT = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestReadASS(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []Segment
+	}{
+		{
+			"default format, override tags and line breaks",
+			[]string{
+				"[Script Info]",
+				"Dialogue: 0,0:00:09.00,0:00:10.00,Default,,0,0,0,,not an event",
+				"[Events]",
+				"Dialogue: 0,0:00:01.00,0:00:02.00,Default,,0,0,0,,{\\i1}一行目{\\i0}\\N二行目",
+				"Comment: 0,0:00:02.00,0:00:03.00,Default,,0,0,0,,コメント",
+				"Dialogue: 0,0:00:03.00,0:00:04.00,Default,,0,0,0,,はい、そう\\hです\\n",
+				"Dialogue: 0,0:00:05.00,0:00:06.00,Default,,0,0,0,,{\\pos(10,10)}",
+			},
+			[]Segment{
+				{Text: "一行目\n二行目", Start: "0:00:01.00", End: "0:00:02.00"},
+				{Text: "はい、そう です\n", Start: "0:00:03.00", End: "0:00:04.00"},
+			},
+		},
+		{
+			"the Format line picks the columns",
+			[]string{
+				"[events]",
+				"Format: Start, End, Text",
+				"Dialogue: 0:00:01.00,0:00:02.00,一、二、三",
+				"Dialogue: too few",
+				"[V4+ Styles]",
+				"Dialogue: 0:00:03.00,0:00:04.00,styles aren't events",
+			},
+			[]Segment{{Text: "一、二、三", Start: "0:00:01.00", End: "0:00:02.00"}},
+		},
+	}
+	for _, test := range tests {
+		if got := ReadASS(test.lines); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: ReadASS = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// KanjiSet is a group of kanji the vault can be measured against, a JLPT level or a school grade
+type KanjiSet struct {
+	Name    string
+	Kanji   []string
+	Have    []string
+	Missing []string
+}
+
+// LoadKanjidic reads a full kanjidic2.xml to use instead of the bundled one
+func loadKanjidic(path string) (Kanjidic2, error) {
+	var kanjidic Kanjidic2
+	file, err := os.Open(path)
+	if err != nil {
+		return kanjidic, err
+	}
+	defer file.Close()
+
+	decoder := xml.NewDecoder(file)
+	decoder.Strict = false
+	err = decoder.Decode(&kanjidic)
+	return kanjidic, err
+}
+
+// GradeSetName names the set a kanjidic grade belongs to
+func gradeSetName(grade int) string {
+	switch {
+	case grade >= 1 && grade <= 6:
+		return fmt.Sprintf("Grade %d", grade)
+	case grade == 8:
+		return "Secondary"
+	case grade == 9 || grade == 10:
+		return "Jinmeiyo"
+	}
+	return ""
+}
+
+// KanjiSets sorts every kanji in kanjidic into the JLPT levels and the grades, most used first within each
+// A kanji is in an N level when jlptPath's list has it and in its old level from kanjidic otherwise
+func kanjiSets() []*KanjiSet {
+	var sets []*KanjiSet
+	byName := map[string]*KanjiSet{}
+	for _, label := range jlptLabels[:len(jlptLabels)-1] {
+		name := "JLPT " + label
+		byName[name] = &KanjiSet{Name: name}
+		sets = append(sets, byName[name])
+	}
+	for _, grade := range []int{1, 2, 3, 4, 5, 6, 8, 9} {
+		name := gradeSetName(grade)
+		byName[name] = &KanjiSet{Name: name}
+		sets = append(sets, byName[name])
+	}
+
+	for kanji, data := range kanjiIdx {
+		if set, exists := byName["JLPT "+kanjiJLPTLabel(kanji)]; exists {
+			set.Kanji = append(set.Kanji, kanji)
+		}
+		if name := gradeSetName(data.Grade); name != "" {
+			byName[name].Kanji = append(byName[name].Kanji, kanji)
+		}
+	}
+	for _, set := range sets {
+		slices.SortFunc(set.Kanji, func(a, b string) int {
+			if order := compareRanks(kanjiIdx[a].Freq, kanjiIdx[b].Freq); order != 0 {
+				return order
+			}
+			return cmp.Compare(a, b)
+		})
+	}
+	return sets
+}
+
+// CoverageNotePath is where a set's index note goes
+func coverageNotePath(set *KanjiSet) string {
+	return filepath.Join(coveragePath, set.Name+".md")
+}
+
+// WriteCoverage writes an index note for every kanji set and Coverage.md with how much of each the vault has
+func writeCoverage() {
+	summary := []string{"# Kanji Coverage", "", "| Set | In the vault | Total | Coverage |", "|---|---|---|---|"}
+	var empty []string
+	for _, set := range kanjiSets() {
+		// The bundled kanjidic has no grades, a set it knows nothing about gets no note instead of 0 of 0
+		if len(set.Kanji) == 0 {
+			empty = append(empty, set.Name)
+			continue
+		}
+		var have []string
+		for _, kanji := range set.Kanji {
+			path := filepath.Join(kanjiPath, kanji+".md")
+			lines, err := readLines(path)
+			if err != nil {
+				set.Missing = append(set.Missing, kanji)
+				continue
+			}
+			set.Have = append(set.Have, kanji)
+			// The first source in the Tags line is the one that made the note
+			line := fmt.Sprintf("[%s](%s\\%s.md)", kanji, kanjiPath, kanji)
+			if sources := noteSources(lines); len(sources) > 0 {
+				line += fmt.Sprintf(" first in [%s](%s\\%s.md)", sources[0], contentPath, sources[0])
+			}
+			have = append(have, line)
+		}
+
+		note := []string{
+			"# " + set.Name,
+			fmt.Sprintf("In the vault: %d of %d (%.1f%%)", len(set.Have), len(set.Kanji), percent(len(set.Have), len(set.Kanji))),
+			"",
+			"## In The Vault",
+		}
+		note = append(note, have...)
+		note = append(note, "", "## Missing", strings.Join(set.Missing, " "), "")
+		path := coverageNotePath(set)
+		if err := os.WriteFile(path, []byte(strings.Join(note, "\n")), 0644); err != nil {
+			fmt.Printf("Error writing to %s: %v\n", path, err)
+		}
+
+		summary = append(summary, fmt.Sprintf("| [%s](%s\\%s.md) | %d | %d | %.1f%% |", set.Name, coveragePath, set.Name, len(set.Have), len(set.Kanji), percent(len(set.Have), len(set.Kanji))))
+	}
+
+	err := os.WriteFile(coverageMd, []byte(strings.Join(summary, "\n")+"\n"), 0644)
+	if err != nil {
+		fmt.Printf("Error writing to %s: %v\n", coverageMd, err)
+	}
+	if len(empty) > 0 {
+		fmt.Printf("No kanji for %s, set kanjidicPath in settings.json to a full kanjidic2.xml for the grades and jlptPath to a list with kanji for the N levels\n", strings.Join(empty, ", "))
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ikawaha/kagome/tokenizer"
+)
+
+// The tag on sentence notes that came from a dictionary or corpus instead of the vault's content
+const externalExampleTag = "external_example"
+
+// Only this many of the shortest candidates are parsed to see how many of their words are known
+const exampleCandidates = 30
+
+// ExamplePair is a Japanese sentence from outside the vault's content and its translation
+type ExamplePair struct {
+	Japanese string
+	English  string
+	Source   string // Where it came from, Tatoeba #1234 or JMdict
+}
+
+var (
+	// Sentence pairs from the Tatoeba export in settings.json, shortest first
+	tatoebaPairs []ExamplePair
+	// The pairs each word is used in by its dictionary form and as written, so 見た is found for 見る
+	tatoebaIndex = map[string][]int{}
+)
+
+// LoadTatoeba reads Tatoeba's sentence pairs download, id<TAB>Japanese<TAB>id<TAB>English on each line, or a plain Japanese<TAB>English list
+// Every sentence is tokenized once here so a word card only has to look its word up
+func loadTatoeba(path string) error {
+	lines, err := readLines(path)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		fields := strings.Split(line, "\t")
+		var pair ExamplePair
+		switch {
+		case len(fields) >= 4:
+			pair = ExamplePair{Japanese: fields[1], English: fields[3], Source: "Tatoeba #" + fields[0]}
+		case len(fields) == 2:
+			pair = ExamplePair{Japanese: fields[0], English: fields[1], Source: "Tatoeba"}
+		default:
+			continue
+		}
+		pair.Japanese, pair.English = strings.TrimSpace(pair.Japanese), strings.TrimSpace(pair.English)
+		if pair.Japanese != "" && pair.English != "" {
+			tatoebaPairs = append(tatoebaPairs, pair)
+		}
+	}
+
+	// Short sentences make the best examples, with the pairs in that order each word's list is too
+	length := func(pair ExamplePair) int { return utf8.RuneCountInString(pair.Japanese) }
+	slices.SortStableFunc(tatoebaPairs, func(a, b ExamplePair) int { return cmp.Compare(length(a), length(b)) })
+	for i, pair := range tatoebaPairs {
+		seen := map[string]bool{}
+		for _, token := range tokenize(pair.Japanese) {
+			if token.Class == tokenizer.DUMMY {
+				continue
+			}
+			// User dictionary tokens have no base form
+			base := token.Surface
+			if features := tokenFeatures(token); len(features) > 6 {
+				base = features[6]
+			}
+			for _, key := range []string{base, token.Surface} {
+				if key != "" && key != "*" && !seen[key] {
+					seen[key] = true
+					tatoebaIndex[key] = append(tatoebaIndex[key], i)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// LoadJMdict reads a full JMdict to use instead of the bundled one, JMdict_e_examp.xml has the example sentences the bundled one leaves out
+func loadJMdict(path string) (JMdict, error) {
+	var jmdict JMdict
+	file, err := os.Open(path)
+	if err != nil {
+		return jmdict, err
+	}
+	defer file.Close()
+
+	decoder := xml.NewDecoder(file)
+	decoder.Strict = false
+	err = decoder.Decode(&jmdict)
+	return jmdict, err
+}
+
+// JMdictExamples pairs up the Japanese and English ex_sent of a sense's examples
+func jmdictExamples(examples []Example) []ExamplePair {
+	var output []ExamplePair
+	for _, example := range examples {
+		pair := ExamplePair{Source: "JMdict"}
+		if example.ExSrce.Type == "tat" && example.ExSrce.Text != "" {
+			pair.Source = "Tatoeba #" + example.ExSrce.Text
+		}
+		for _, sent := range example.ExSent {
+			switch sent.Lang {
+			case "jpn":
+				pair.Japanese = strings.TrimSpace(sent.Text)
+			case "eng", "":
+				pair.English = strings.TrimSpace(sent.Text)
+			}
+		}
+		if pair.Japanese != "" {
+			output = append(output, pair)
+		}
+	}
+	return output
+}
+
+// WordExamples picks up to examplesPerWord sentences for a word from JMdict and Tatoeba, short ones with the fewest unknown words first
+func wordExamples(word string, data []WordData) []ExamplePair {
+	if settings.ExamplesPerWord <= 0 || word == "" {
+		return nil
+	}
+	var candidates []ExamplePair
+	seen := map[string]bool{}
+	add := func(pair ExamplePair) {
+		if !seen[pair.Japanese] {
+			seen[pair.Japanese] = true
+			candidates = append(candidates, pair)
+		}
+	}
+	for _, d := range data {
+		for _, pair := range d.Examples {
+			add(pair)
+		}
+	}
+	// The index lists the shortest sentences first, so the first few are all that can make the cut
+	for _, i := range tatoebaIndex[word][:min(len(tatoebaIndex[word]), exampleCandidates)] {
+		add(tatoebaPairs[i])
+	}
+
+	length := func(pair ExamplePair) int { return utf8.RuneCountInString(pair.Japanese) }
+	slices.SortStableFunc(candidates, func(a, b ExamplePair) int { return cmp.Compare(length(a), length(b)) })
+	candidates = candidates[:min(len(candidates), exampleCandidates)]
+
+	unknown := map[string]int{}
+	for _, pair := range candidates {
+		for _, parsed := range parser(pair.Japanese) {
+			var w Word
+			switch v := parsed.(type) {
+			case Word:
+				w = v
+			case Verb:
+				w = v.Word
+			}
+			if w.DictForm != "" && w.DictForm != word && !slices.Contains(miningSkipped, w.Pos) && !isKnownWord(w.DictForm) {
+				unknown[pair.Japanese]++
+			}
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b ExamplePair) int { return cmp.Compare(unknown[a.Japanese], unknown[b.Japanese]) })
+	return candidates[:min(len(candidates), settings.ExamplesPerWord)]
+}
+
+// WriteExampleNote gives an example its own sentence note, a sentence that already has a note keeps it
+// and gets a link to the word if it doesn't have one yet
+func writeExampleNote(pair ExamplePair, word string) {
+	sentenceNotesMu.Lock()
+	defer sentenceNotesMu.Unlock()
+
+	path := sentenceNotePath(pair.Japanese)
+	link := fmt.Sprintf("Example for: [%s](%s\\%s.md)", word, wordsPath, word)
+	if _, err := os.Stat(path); err == nil {
+		lines, err := readLines(path)
+		if err != nil || containsRune(lines, link) {
+			return
+		}
+		lines = append(lines, link)
+		writeCard(strings.Join(lines, "\n"), path)
+		return
+	}
+	content := []string{
+		"TARGET DECK: Sentences",
+		"START",
+		"Basic",
+		sentenceToWordString(pair.Japanese),
+		fmt.Sprintf("Back: %s", pair.English),
+		"Tags: " + externalExampleTag,
+		"",
+		"END",
+		"Sentence: " + pair.Japanese,
+		"External example: " + pair.Source,
+		link,
+	}
+	writeCard(strings.Join(content, "\n"), path)
+}
+
+// ExternalCard finds the card an example note was made with, the one tagged externalExampleTag
+func externalCard(lines []string) (int, int, bool) {
+	start := -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case "START":
+			start = i
+		case "END":
+			if start < 0 {
+				continue
+			}
+			for _, card := range lines[start:i] {
+				if strings.HasPrefix(strings.TrimSpace(card), "Tags: ") && slices.Contains(strings.Fields(card), externalExampleTag) {
+					return start, i, true
+				}
+			}
+			start = -1
+		}
+	}
+	return 0, 0, false
+}
+
+// ReplaceExternalCard swaps an example note's external card for the content's own, the sentence isn't an external example anymore
+// but it's still an example for the words it links
+func replaceExternalCard(lines, block []string) []string {
+	start, end, ok := externalCard(lines)
+	if !ok {
+		return lines
+	}
+	lines = slices.Concat(lines[:start], block, lines[end+1:])
+	return slices.DeleteFunc(lines, func(line string) bool { return strings.HasPrefix(line, "External example: ") })
+}
+
+// ExampleLines writes a word's examples as sentence notes and returns the lines that link them from the word card
+func exampleLines(word string, data []WordData) []string {
+	var output []string
+	for _, pair := range wordExamples(word, data) {
+		writeExampleNote(pair, word)
+		output = append(output, fmt.Sprintf("Example: %s %s", sentenceLink(pair.Japanese), pair.English))
+	}
+	return output
+}
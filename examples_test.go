@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReplaceExternalCard(t *testing.T) {
+	example := []string{
+		"TARGET DECK: Sentences",
+		"START",
+		"Basic",
+		"猫が好き。",
+		"Back: I like cats.",
+		"Tags: " + externalExampleTag,
+		"",
+		"END",
+		"Sentence: 猫が好き。",
+		"External example: Tatoeba #1",
+		"Example for: [猫](Words\\猫.md)",
+	}
+	content := []string{"START", "Basic", "猫が好き。", "Back: ", "Tags: [Show](Content\\Show.md)", "", "END"}
+
+	want := []string{
+		"TARGET DECK: Sentences",
+		"START", "Basic", "猫が好き。", "Back: ", "Tags: [Show](Content\\Show.md)", "", "END",
+		"Sentence: 猫が好き。",
+		"Example for: [猫](Words\\猫.md)",
+	}
+	if got := replaceExternalCard(example, content); !reflect.DeepEqual(got, want) {
+		t.Errorf("replaceExternalCard = %q, want %q", got, want)
+	}
+
+	// A note from content has nothing to replace
+	if got := replaceExternalCard(want, content); !reflect.DeepEqual(got, want) {
+		t.Errorf("a content note was changed: %q", got)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ranks from the frequency list in settings.json, 1 is the most common word
+var (
+	wordRanks    = map[string]int{} // By lemma, the best rank of any of its readings
+	readingRanks = map[string]int{} // By lemma and reading
+)
+
+// LoadFrequency reads a frequency list with lemma<TAB>reading<TAB>rank on each line, the reading can be left empty
+func loadFrequency(path string) error {
+	lines, err := readLines(path)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			continue
+		}
+		lemma, reading := strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1])
+		// Header lines don't have a number
+		rank, err := strconv.Atoi(strings.TrimSpace(fields[2]))
+		if err != nil || rank < 1 || lemma == "" {
+			continue
+		}
+		if best, exists := wordRanks[lemma]; !exists || rank < best {
+			wordRanks[lemma] = rank
+		}
+		if reading != "" {
+			if best, exists := readingRanks[lemma+"\t"+reading]; !exists || rank < best {
+				readingRanks[lemma+"\t"+reading] = rank
+			}
+		}
+	}
+	return nil
+}
+
+// WordRank returns a word's rank, going by its reading when the list has it, 0 if the word isn't on the list
+func wordRank(word string, readings ...string) int {
+	for _, reading := range readings {
+		if rank, exists := readingRanks[word+"\t"+reading]; exists {
+			return rank
+		}
+	}
+	return wordRanks[word]
+}
+
+// CompareRanks orders the most common first, anything without a rank goes last
+func compareRanks(a, b int) int {
+	switch {
+	case a == b:
+		return 0
+	case a == 0:
+		return 1
+	case b == 0:
+		return -1
+	}
+	return cmp.Compare(a, b)
+}
+
+// Lines under a card that order it in the export, word ranks come from the frequency list and kanji ranks
+// from kanjidic, so the two get different names and are only compared within their own deck
+const (
+	rankPrefix      = "Rank: "
+	kanjiRankPrefix = "Kanji rank: "
+)
+
+// RankLine is what goes under a word card so the export can order it, empty without a rank
+func rankLine(rank int) []string {
+	if rank == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s%d", rankPrefix, rank)}
+}
+
+// KanjiRankLine is what goes under a kanji card so the export can order it, empty without a rank
+func kanjiRankLine(rank int) []string {
+	if rank == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s%d", kanjiRankPrefix, rank)}
+}
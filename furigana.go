@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/settings_handler"
+	"github.com/ikawaha/kagome/tokenizer"
+)
+
+// FuriganaPart is a run of text and the reading that sits above it
+type FuriganaPart struct {
+	Text    string
+	Reading string
+}
+
+// KatakanaToHiragana shifts katakana into the hiragana block, leaving everything else alone
+func katakanaToHiragana(s string) string {
+	var result strings.Builder
+	for _, r := range s {
+		if r >= 'ァ' && r <= 'ヶ' {
+			r -= 0x60
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
+
+// HiraganaToKatakana shifts hiragana into the katakana block, the way kagome writes readings
+func hiraganaToKatakana(s string) string {
+	var result strings.Builder
+	for _, r := range s {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			r += 0x60
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
+
+// IsKanjiChar reports whether a character should carry furigana
+func isKanjiChar(c string) bool {
+	return c == "々" || c == "〆" || c == "ヶ" || containsRune(kanjiSet, c)
+}
+
+// TokenReading returns the hiragana reading kagome gives a token, or "" if it has none
+func tokenReading(token tokenizer.Token) string {
+	features := tokenFeatures(token)
+	if token.Class == tokenizer.USER {
+		return katakanaToHiragana(strings.ReplaceAll(features[2], "/", ""))
+	}
+	if len(features) < 8 || features[7] == "*" {
+		return ""
+	}
+	return katakanaToHiragana(features[7])
+}
+
+// AlignFurigana splits a surface into kanji runs and kana runs and gives each kanji run its share of the reading
+// 食べる + たべる => [食 た] [べる]
+func alignFurigana(surface, reading string) []FuriganaPart {
+	reading = katakanaToHiragana(reading)
+
+	var runs []FuriganaPart
+	hasKanji := false
+	for _, c := range surface {
+		charStr := string(c)
+		kanji := isKanjiChar(charStr)
+		hasKanji = hasKanji || kanji
+		// kanji runs are marked with a non-empty reading placeholder until aligned
+		if len(runs) > 0 && (runs[len(runs)-1].Reading != "") == kanji {
+			runs[len(runs)-1].Text += charStr
+			continue
+		}
+		placeholder := ""
+		if kanji {
+			placeholder = "?"
+		}
+		runs = append(runs, FuriganaPart{Text: charStr, Reading: placeholder})
+	}
+
+	if !hasKanji || reading == "" {
+		return []FuriganaPart{{Text: surface}}
+	}
+	if matchFurigana(runs, []rune(reading)) {
+		return runs
+	}
+	// Couldn't line the kana up, so the whole word gets the whole reading
+	return []FuriganaPart{{Text: surface, Reading: reading}}
+}
+
+// MatchFurigana fills in the readings of kanji runs, kana runs have to match the reading literally
+func matchFurigana(runs []FuriganaPart, reading []rune) bool {
+	if len(runs) == 0 {
+		return len(reading) == 0
+	}
+	run := runs[0]
+	if run.Reading == "" {
+		kana := []rune(katakanaToHiragana(run.Text))
+		if len(kana) > len(reading) || string(reading[:len(kana)]) != string(kana) {
+			return false
+		}
+		return matchFurigana(runs[1:], reading[len(kana):])
+	}
+
+	for i := 1; i <= len(reading); i++ {
+		if matchFurigana(runs[1:], reading[i:]) {
+			runs[0].Reading = string(reading[:i])
+			return true
+		}
+	}
+	return false
+}
+
+// RenderFurigana writes aligned parts in the configured furigana mode
+func renderFurigana(parts []FuriganaPart) string {
+	var result strings.Builder
+	for _, p := range parts {
+		if p.Reading == "" {
+			result.WriteString(p.Text)
+			continue
+		}
+		switch settings.FuriganaMode {
+		case settings_handler.FuriganaAnki:
+			// Anki needs a space to know where the base text starts
+			if result.Len() > 0 {
+				result.WriteString(" ")
+			}
+			result.WriteString(fmt.Sprintf("%s[%s]", p.Text, p.Reading))
+		case settings_handler.FuriganaHTML:
+			result.WriteString(fmt.Sprintf("<ruby>%s<rt>%s</rt></ruby>", p.Text, p.Reading))
+		case settings_handler.FuriganaMarkdown:
+			result.WriteString(fmt.Sprintf("%s(%s)", p.Text, p.Reading))
+		default:
+			result.WriteString(p.Text)
+		}
+	}
+	return result.String()
+}
+
+// TokenFuriganaParts aligns kagome's token readings with the text
+func tokenFuriganaParts(text string) []FuriganaPart {
+	var parts []FuriganaPart
+	for _, token := range tokenize(text) {
+		if token.Class == tokenizer.DUMMY {
+			continue
+		}
+		parts = append(parts, alignFurigana(token.Surface, tokenReading(token))...)
+	}
+	return parts
+}
+
+// SentenceFurigana renders a sentence with furigana using kagome's token readings
+func sentenceFurigana(sentence string) string {
+	if settings.FuriganaMode == settings_handler.FuriganaNone {
+		return ""
+	}
+	return renderFurigana(tokenFuriganaParts(sentence))
+}
+
+// SourceFurigana renders a sentence with furigana, readings written in the source win over kagome's
+func sourceFurigana(sentence Sentence) string {
+	if settings.FuriganaMode == settings_handler.FuriganaNone {
+		return ""
+	}
+	var parts []FuriganaPart
+	rest := sentence.Text
+	for _, ruby := range sentence.Segment.Readings {
+		i := strings.Index(rest, ruby.Base)
+		if i < 0 {
+			continue
+		}
+		parts = append(parts, tokenFuriganaParts(rest[:i])...)
+		parts = append(parts, FuriganaPart{Text: ruby.Base, Reading: ruby.Reading})
+		rest = rest[i+len(ruby.Base):]
+	}
+	parts = append(parts, tokenFuriganaParts(rest)...)
+	return renderFurigana(parts)
+}
+
+// ReadingFurigana renders text with furigana from a reading we already know
+func readingFurigana(text, reading string) string {
+	if settings.FuriganaMode == settings_handler.FuriganaNone {
+		return ""
+	}
+	return renderFurigana(alignFurigana(text, reading))
+}
+
+// Readings the current source gives in its ruby markup, by base text
+var sourceReadings = map[string]string{}
+
+// WordFurigana renders a word with furigana, the source's own ruby wins, then JMdict, then the tokenizer's guess
+func wordFurigana(word string) string {
+	if reading, exists := sourceReadings[word]; exists {
+		return readingFurigana(word, reading)
+	}
+	for _, wd := range WordLookup(word) {
+		reading := strings.TrimSpace(strings.Split(wd.Reading, ",")[0])
+		if reading == "" || reading == word {
+			continue
+		}
+		return readingFurigana(word, reading)
+	}
+	return sentenceFurigana(word)
+}
+
+// ContentFurigana renders every line of a content blob with furigana
+func contentFurigana(blob string) string {
+	if settings.FuriganaMode == settings_handler.FuriganaNone {
+		return ""
+	}
+	var lines []string
+	for _, line := range strings.Split(blob, "\n") {
+		if line == "" {
+			continue
+		}
+		lines = append(lines, sentenceFurigana(line))
+	}
+	return strings.Join(lines, "\n")
+}
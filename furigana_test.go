@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAlignFurigana(t *testing.T) {
+	tests := []struct {
+		surface, reading string
+		want             []FuriganaPart
+	}{
+		{"食べる", "タベル", []FuriganaPart{{"食", "た"}, {"べる", ""}}},
+		{"日本", "ニホン", []FuriganaPart{{"日本", "にほん"}}},
+		{"お茶", "おちゃ", []FuriganaPart{{"お", ""}, {"茶", "ちゃ"}}},
+		{"取り消す", "とりけす", []FuriganaPart{{"取", "と"}, {"り", ""}, {"消", "け"}, {"す", ""}}},
+		{"人々", "ひとびと", []FuriganaPart{{"人々", "ひとびと"}}},
+		{"ひらがな", "ひらがな", []FuriganaPart{{"ひらがな", ""}}},
+		{"食べる", "", []FuriganaPart{{"食べる", ""}}},
+		// The kana don't fit the reading, so the whole word takes it
+		{"今日は", "きょうわ", []FuriganaPart{{"今日は", "きょうわ"}}},
+	}
+	for _, test := range tests {
+		if got := alignFurigana(test.surface, test.reading); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("alignFurigana(%q, %q) = %v, want %v", test.surface, test.reading, got, test.want)
+		}
+	}
+}
@@ -4,7 +4,22 @@ go 1.24.2
 
 require (
 	github.com/ikawaha/kagome v1.11.2
+	github.com/klauspost/compress v1.17.11
 	github.com/therecipe/qt v0.0.0-20200904063919-c0c124a5770d
+	golang.org/x/net v0.38.0
+	golang.org/x/text v0.23.0
+	modernc.org/sqlite v1.34.5
 )
 
-require github.com/gopherjs/gopherjs v0.0.0-20190411002643-bd77b112433e // indirect
+require (
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/gopherjs/gopherjs v0.0.0-20190411002643-bd77b112433e // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/ncruces/go-strftime v0.1.9 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	modernc.org/libc v1.55.3 // indirect
+	modernc.org/mathutil v1.6.0 // indirect
+	modernc.org/memory v1.8.0 // indirect
+)
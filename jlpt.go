@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JLPT levels of words from the list in settings.json, 5 for N5 through 1 for N1
+var jlptWords = map[string]int{}
+
+// LoadJLPT reads a word list with a level on each line: 食べる<TAB>N5, commas work too and the N is optional
+func loadJLPT(path string) error {
+	lines, err := readLines(path)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		fields := strings.FieldsFunc(line, func(r rune) bool { return r == '\t' || r == ',' })
+		if len(fields) < 2 {
+			continue
+		}
+		level, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(fields[1])), "N"))
+		if err != nil || level < 1 || level > 5 {
+			continue
+		}
+		jlptWords[strings.TrimSpace(fields[0])] = level
+	}
+	return nil
+}
+
+// KanjiJLPT returns a kanji's N level from the word list, 0 if the list doesn't have it on its own line
+func kanjiJLPT(kanji string) int {
+	return jlptWords[kanji]
+}
+
+// KanjiOldJLPT returns a kanji's level on the old four level test from kanjidic, 0 if it wasn't on it
+// Old level 2 became both N3 and N2, so the old levels are kept apart from the N levels instead of being mapped onto them
+func kanjiOldJLPT(kanji string) int {
+	return KanjiLookup(kanji).JLPT
+}
+
+// HardestLevel guesses a word's level from its hardest kanji, 0 if one of its kanji has no level
+// Both tests count down, so the hardest is the lowest
+func hardestLevel(word string, kanjiLevel func(string) int) int {
+	level := 0
+	for _, c := range word {
+		if !isKanjiChar(string(c)) {
+			continue
+		}
+		kanji := kanjiLevel(string(c))
+		if kanji == 0 {
+			// A kanji that was never on the test, the word is harder than anything we can name
+			return 0
+		}
+		if level == 0 || kanji < level {
+			level = kanji
+		}
+	}
+	return level
+}
+
+// WordJLPT returns a word's N level from the word list, or guesses it from its hardest kanji, 0 if neither knows
+func wordJLPT(word string) int {
+	if level, exists := jlptWords[word]; exists {
+		return level
+	}
+	return hardestLevel(word, kanjiJLPT)
+}
+
+// JLPTLabel writes a level as N5 through N1, or unlisted
+func jlptLabel(level int) string {
+	if level == 0 {
+		return "unlisted"
+	}
+	return fmt.Sprintf("N%d", level)
+}
+
+// OldJLPTLabel writes an old level as Old 4 through Old 1
+func oldJLPTLabel(level int) string {
+	return fmt.Sprintf("Old %d", level)
+}
+
+// Labels in the order they're shown, the N levels from the word list and then the old levels from kanjidic
+var jlptLabels = []string{"N5", "N4", "N3", "N2", "N1", "Old 4", "Old 3", "Old 2", "Old 1", "unlisted"}
+
+// KanjiJLPTLabel is the level a kanji is counted under, its N level when the word list has one and its old level otherwise
+func kanjiJLPTLabel(kanji string) string {
+	if level := kanjiJLPT(kanji); level != 0 {
+		return jlptLabel(level)
+	}
+	if level := kanjiOldJLPT(kanji); level != 0 {
+		return oldJLPTLabel(level)
+	}
+	return jlptLabel(0)
+}
+
+// WordJLPTLabel is the level a word is counted under, its N level when the word list or its kanji give one
+// and otherwise the old level of its hardest kanji
+func wordJLPTLabel(word string) string {
+	if level := wordJLPT(word); level != 0 {
+		return jlptLabel(level)
+	}
+	if level := hardestLevel(word, kanjiOldJLPT); level != 0 {
+		return oldJLPTLabel(level)
+	}
+	return jlptLabel(0)
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Where a known item was learned about
+const (
+	KnownFromList = "list"
+	KnownFromAnki = "anki"
+	KnownFromNote = "note"
+)
+
+// The tag that marks a word or kanji note as known
+const knownTag = "known"
+
+// KnownItem is a word or kanji that doesn't need a card any more
+type KnownItem struct {
+	Source   string `json:"source"`
+	Maturity string `json:"maturity,omitempty"` // Only items imported from an Anki collection have a maturity and interval
+	Interval int    `json:"interval,omitempty"`
+}
+
+// KnownStore is every known word and kanji, by the word or kanji itself
+type KnownStore struct {
+	Words map[string]KnownItem `json:"words"`
+	Kanji map[string]KnownItem `json:"kanji"`
+}
+
+var knownStore = KnownStore{Words: map[string]KnownItem{}, Kanji: map[string]KnownItem{}}
+
+var (
+	markdownLinkRe = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	bracketRe      = regexp.MustCompile(`\[[^\]]*\]`)
+	htmlTagRe      = regexp.MustCompile(`<[^>]*>`)
+)
+
+// LoadKnown reads the known store
+func loadKnown() {
+	data, err := os.ReadFile(knownJson)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Printf("Error reading %s: %v\n", knownJson, err)
+	}
+	if err == nil {
+		store := KnownStore{}
+		if err := json.Unmarshal(data, &store); err != nil {
+			fmt.Printf("Error decoding %s: %v\n", knownJson, err)
+		} else {
+			knownStore = store
+		}
+	}
+	if knownStore.Words == nil {
+		knownStore.Words = map[string]KnownItem{}
+	}
+	if knownStore.Kanji == nil {
+		knownStore.Kanji = map[string]KnownItem{}
+	}
+}
+
+// SaveKnown writes the known store
+func saveKnown() {
+	data, err := json.MarshalIndent(knownStore, "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding %s: %v\n", knownJson, err)
+		return
+	}
+	if err := os.WriteFile(knownJson, data, 0644); err != nil {
+		fmt.Printf("Error writing to %s: %v\n", knownJson, err)
+	}
+}
+
+func isKnownWord(word string) bool {
+	item, exists := knownStore.Words[word]
+	return exists && knownEnough(item)
+}
+
+func isKnownKanji(kanji string) bool {
+	item, exists := knownStore.Kanji[kanji]
+	return exists && knownEnough(item)
+}
+
+// KnownEnough reports whether an item counts as known, Anki cards have to be at least as far along as knownMaturity in settings.json
+func knownEnough(item KnownItem) bool {
+	if item.Maturity == "" {
+		return true
+	}
+	return maturityRank[item.Maturity] >= maturityRank[settings.KnownMaturity]
+}
+
+// ScanKnownMarks adds every word, name and kanji note with known in its Tags line to the store, returning how many were new
+func scanKnownMarks() int {
+	added := 0
+	scan := func(dir string, store map[string]KnownItem) {
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".md" {
+				return nil
+			}
+			item := strings.TrimSuffix(filepath.Base(path), ".md")
+			if _, exists := store[item]; exists || !markedKnown(path) {
+				return nil
+			}
+			store[item] = KnownItem{Source: KnownFromNote}
+			added++
+			return nil
+		})
+	}
+	scan(wordsPath, knownStore.Words)
+	scan(namesPath, knownStore.Words)
+	scan(kanjiPath, knownStore.Kanji)
+	return added
+}
+
+// MarkedKnown reports whether a note's Tags line has the known tag
+func markedKnown(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "Tags:") {
+			continue
+		}
+		for _, tag := range strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "Tags:")) {
+			if strings.EqualFold(strings.TrimPrefix(tag, "#"), knownTag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// KnownItemText gets the word or kanji out of an exported card field, dropping links, furigana and HTML
+func knownItemText(field string) string {
+	field = markdownLinkRe.ReplaceAllString(field, "$1")
+	field = htmlTagRe.ReplaceAllString(field, "")
+	field = bracketRe.ReplaceAllString(field, "")
+	field = strings.ReplaceAll(field, "&nbsp;", " ")
+	// Kanji cards have the stroke count after the kanji
+	field, _, _ = strings.Cut(field, ",")
+	return strings.TrimSpace(strings.ReplaceAll(field, " ", ""))
+}
+
+// ImportKnownList adds the items in a plain list, or in Anki's "Notes in Plain Text" export, to the store
+// One item per line, for tab separated lines field picks the column, counting from 1
+func importKnownList(path string, store map[string]KnownItem, source string, field int) (int, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, line := range lines {
+		// Anki puts #separator:tab and the like at the top of its exports
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		columns := strings.Split(line, "\t")
+		if field > len(columns) {
+			continue
+		}
+		item := knownItemText(strings.Trim(columns[field-1], `"`))
+		if item == "" {
+			continue
+		}
+		if _, exists := store[item]; !exists {
+			added++
+		}
+		store[item] = KnownItem{Source: source}
+	}
+	return added, nil
+}
+
+// KnownCommand manages the known store
+//
+//	known add word|kanji <item>...
+//	known import <file> [word|kanji] [field]
+//	known anki <export.apkg|.colpkg|.txt> [word|kanji] [field]
+//	known scan
+func knownCommand(args []string) {
+	usage := "Usage: known add word|kanji <item>... | known import <file> [word|kanji] [field] | known anki <export.apkg|.colpkg|.txt> [word|kanji] [field] | known scan"
+	if len(args) == 0 {
+		fmt.Println(usage)
+		return
+	}
+	loadKnown()
+	defer saveKnown()
+
+	stores := map[string]map[string]KnownItem{
+		"word":  knownStore.Words,
+		"kanji": knownStore.Kanji,
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) < 3 {
+			fmt.Println(usage)
+			return
+		}
+		store, exists := stores[args[1]]
+		if !exists {
+			fmt.Println(usage)
+			return
+		}
+		for _, item := range args[2:] {
+			store[item] = KnownItem{Source: KnownFromList}
+		}
+		fmt.Printf("Marked %d items known\n", len(args)-2)
+	case "import", "anki":
+		if len(args) < 2 {
+			fmt.Println(usage)
+			return
+		}
+		kind, field := "word", 1
+		if len(args) > 2 {
+			kind = args[2]
+		}
+		if _, exists := stores[kind]; !exists {
+			fmt.Println(usage)
+			return
+		}
+		if len(args) > 3 {
+			n, err := strconv.Atoi(args[3])
+			if err != nil || n < 1 {
+				fmt.Printf("Field must be a column number, not %s\n", args[3])
+				return
+			}
+			field = n
+		}
+		if args[0] == "anki" {
+			if err := importAnki(args[1], kind, field); err != nil {
+				fmt.Printf("Error importing %s: %v\n", args[1], err)
+			}
+			return
+		}
+		added, err := importKnownList(args[1], stores[kind], KnownFromList, field)
+		if err != nil {
+			fmt.Printf("Error importing %s: %v\n", args[1], err)
+			return
+		}
+		fmt.Printf("Imported %d new known items from %s\n", added, args[1])
+	case "scan":
+		fmt.Printf("Marked %d notes known\n", scanKnownMarks())
+	default:
+		fmt.Println(usage)
+	}
+}
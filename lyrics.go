@@ -0,0 +1,30 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/settings_handler"
+)
+
+// IsLyrics reports whether a source is read as song lyrics, a line at a time, going by lyricsMode in settings.json
+func isLyrics(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".lrc":
+		return settings.LyricsMode != settings_handler.LyricsOff
+	case ".txt":
+		return settings.LyricsMode == settings_handler.LyricsText
+	}
+	return false
+}
+
+// HasJapanese reports whether a line has any kana or kanji in it
+func hasJapanese(line string) bool {
+	for _, r := range line {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"cmp"
 	_ "embed"
 	"encoding/csv"
 	"encoding/json"
@@ -9,13 +10,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"runtime/debug"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/content_reader"
 	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/path_handler"
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/sentence_splitter"
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/settings_handler"
 	"github.com/ikawaha/kagome/tokenizer"
 	"github.com/therecipe/qt/widgets"
 )
@@ -88,7 +93,7 @@ type ExSrce struct {
 }
 
 type ExSent struct {
-	Lang string `xml:"xml:lang,attr"`
+	Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"` // xml:lang, the decoder turns the xml prefix into its namespace
 	Text string `xml:",chardata"`
 }
 
@@ -102,6 +107,7 @@ type Kanjidic2 struct {
 type Character struct {
 	Literal        string         `xml:"literal"`
 	Misc           Misc           `xml:"misc"`
+	FlatMisc                      // The bundled kanjidic2.xml has the misc fields straight on the character
 	ReadingMeaning ReadingMeaning `xml:"reading_meaning"`
 }
 
@@ -110,6 +116,18 @@ type Misc struct {
 	StrokeCount []int `xml:"stroke_count"`
 	Freq        int   `xml:"freq"`
 	JLPT        int   `xml:"jlpt"`
+	Grade       int   `xml:"grade"`
+}
+
+// FlatMisc is Misc when it sits straight on the character
+type FlatMisc Misc
+
+// GetMisc returns the misc fields wherever the dictionary put them
+func (c Character) getMisc() Misc {
+	if len(c.Misc.StrokeCount) > 0 || c.Misc.Freq != 0 || c.Misc.JLPT != 0 || c.Misc.Grade != 0 {
+		return c.Misc
+	}
+	return Misc(c.FlatMisc)
 }
 
 // ReadingMeaning contains readings and meanings
@@ -154,12 +172,16 @@ type KanjiData struct {
 	Readings string
 	Strokes  int
 	Radicals string
+	Freq     int // Rank among the 2500 most used kanji, 0 if it isn't one
+	JLPT     int // Old four level JLPT, 4 is the easiest, 0 if it wasn't on the test
+	Grade    int // 1 to 6 for the school year it's taught in, 8 for the rest of the Jōyō, 9 and 10 for Jinmeiyō
 }
 type Word struct {
 	Pos      string
 	DictForm string
 	Form     string
 	Word     string
+	Unknown  bool
 }
 type Augmentation struct {
 	Description string
@@ -173,6 +195,7 @@ type WordData struct {
 	Word        string
 	Definitions string
 	Reading     string
+	Examples    []ExamplePair // JMdict's example sentences for the entry
 }
 
 //go:embed kanjidic2.xml
@@ -185,6 +208,12 @@ var verbFormMap = map[string]string{
 	"五段": "godan",
 	"サ変": "suru",
 	"カ変": "kuru",
+
+	// UniDic labels
+	"下一段":  "ichidan",
+	"上一段":  "ichidan",
+	"サ行変格": "suru",
+	"カ行変格": "kuru",
 }
 var posMap = map[string]string{
 	// Nouns
@@ -234,6 +263,15 @@ var posMap = map[string]string{
 	"その他":  "other",
 	"フィラー": "filler",
 	"感動詞":  "interjection",
+
+	// UniDic labels
+	"普通名詞":  "general",
+	"非自立可能": "dependent",
+	"形状詞":   "na_adjective_stem",
+	"接頭辞":   "prefix",
+	"接尾辞":   "suffix",
+	"連体詞":   "adnominal",
+	"補助記号":  "symbol",
 }
 
 type SentenceData struct {
@@ -241,29 +279,55 @@ type SentenceData struct {
 	Translation string
 }
 
+// Sentence is a sentence cut from a source and the segment of the source it came from
+type Sentence struct {
+	Text    string
+	Segment content_reader.Segment
+	Count   int // How many times the sentence comes up in its source, only lyrics are counted instead of repeated
+}
+
 type FlashcardDict struct {
-	Front string
-	Back  string
-	Cloze string
+	Front    string
+	Back     string
+	Cloze    string
+	Priority int    // The note's Rank or Kanji rank line, 0 if it has none, only comparable within a deck
+	Note     string // The note the card is in
 }
 
 // Global variables
 var (
-	pathing        = path_handler.TestPathing
-	oldKanji       []string
-	oldWords       []string
-	contentMd      = pathing.ContentMd
-	kanjiMd        = pathing.KanjiMd
-	sentencesMd    = pathing.SentencesMd
-	wordsMd        = pathing.WordsMd
-	contentPath    = pathing.ContentPath
-	kanjiPath      = pathing.KanjiPath
-	sentencesPath  = pathing.SentencesPath
-	wordsPath      = pathing.WordsPath
-	csvPath        = pathing.CsvPath
-	newContentPath = pathing.NewContent
-	currentName    = ""
-	skipSentences  = false
+	pathing          = path_handler.TestPathing
+	settings         = settings_handler.DefaultSettings()
+	oldKanji         []string
+	oldWords         []string
+	oldNames         []string
+	contentMd        = pathing.ContentMd
+	kanjiMd          = pathing.KanjiMd
+	sentencesMd      = pathing.SentencesMd
+	wordsMd          = pathing.WordsMd
+	namesMd          = pathing.NamesMd
+	unknownMd        = pathing.UnknownMd
+	unknownJson      = pathing.UnknownJson
+	knownJson        = pathing.KnownJson
+	statsMd          = pathing.StatsMd
+	statsJson        = pathing.StatsJson
+	userDicPath      = pathing.UserDic
+	contentPath      = pathing.ContentPath
+	kanjiPath        = pathing.KanjiPath
+	sentencesPath    = pathing.SentencesPath
+	wordsPath        = pathing.WordsPath
+	namesPath        = pathing.NamesPath
+	seriesPath       = pathing.SeriesPath
+	coveragePath     = pathing.CoveragePath
+	coverageMd       = pathing.CoverageMd
+	reviewJson       = pathing.ReviewJson
+	translationsJson = pathing.TranslationsJson
+	csvPath          = pathing.CsvPath
+	newContentPath   = pathing.NewContent
+	currentName      = ""
+	skipSentences    = false
+	// Every prompt reads stdin through this one scanner, a second scanner could buffer lines meant for another
+	stdin = bufio.NewScanner(os.Stdin)
 )
 
 // Unicode ranges for kanji detection
@@ -288,7 +352,7 @@ func init() {
 	}
 
 	// Create directories if they don't exist
-	dirs := []string{contentPath, kanjiPath, sentencesPath, wordsPath, csvPath, "./New Content"}
+	dirs := []string{contentPath, kanjiPath, sentencesPath, wordsPath, namesPath, seriesPath, coveragePath, csvPath, "./New Content"}
 	for _, dir := range dirs {
 		if _, err := os.Stat(dir); os.IsNotExist(err) {
 			os.MkdirAll(dir, 0755)
@@ -296,7 +360,7 @@ func init() {
 	}
 
 	// Create markdown index files if they don't exist
-	files := []string{contentMd, kanjiMd, sentencesMd, wordsMd}
+	files := []string{contentMd, kanjiMd, sentencesMd, wordsMd, namesMd}
 	for _, file := range files {
 		if _, err := os.Stat(file); os.IsNotExist(err) {
 			f, _ := os.Create(file)
@@ -318,7 +382,7 @@ func handleVerbs(dictForm, verbType, form, base string) Verb {
 		},
 		Augmentations: []Augmentation{},
 	}
-	if strings.Contains(verbType, "五段") {
+	if getVerbType(verbType) == "godan" {
 		//godan
 		if getEnglishPOS(form) != "continuative" {
 			switch form {
@@ -415,16 +479,41 @@ func parser(item string) []any {
 	// No multi verbs *Do I really want that though?*
 	// No comprehensive map from augmentations to their respective definitions* particularly for verbs
 	// the verb type sucks but it probably won't end up changing :c
-	t := tokenizer.New()
-	tokens := t.Tokenize(item)
+	tokens := tokenize(item)
 	var output []any
 	var currentVerb Verb
 	for i, token := range tokens {
-		if token.Class != tokenizer.KNOWN {
+		if token.Class == tokenizer.DUMMY {
 			continue
 		}
-		features := token.Features()
+		features := tokenFeatures(token)
 		pos := getEnglishPOS(features[0])
+		if pos == "noun" && getEnglishPOS(features[1]) == "proper_noun" {
+			pos = "proper_noun"
+		}
+		if isOpaque(token.Surface) || strings.TrimSpace(token.Surface) == "" {
+			// Latin words, numbers and the spaces between them stay in the sentence but aren't looked up
+			output = append(output, Word{Pos: "opaque", DictForm: token.Surface, Word: token.Surface})
+			continue
+		}
+		if token.Class == tokenizer.USER {
+			// The user dictionary decides how this span is split
+			for _, word := range userDicWords(token) {
+				output = append(output, word)
+			}
+			continue
+		}
+		if token.Class == tokenizer.UNKNOWN {
+			if hasJapanese(token.Surface) {
+				// Slang, loanwords, names and typos are kept so they can be reviewed in Unknown.md
+				if word, keep := resolveUnknown(token.Surface, pos); keep {
+					output = append(output, word)
+				}
+				continue
+			}
+			// ♪, 〜 and emoji aren't words, they end a verb the way punctuation does
+			pos = "symbol"
+		}
 
 		if pos == "symbol" {
 			if currentVerb.Word.DictForm != "" {
@@ -671,6 +760,13 @@ func parser(item string) []any {
 	}
 	return output
 }
+
+// GetVerbType names a conjugation type's verb class, 五段・カ行イ音便 from IPA and 五段-カ行 from UniDic are both godan
+func getVerbType(s string) string {
+	label, _, _ := strings.Cut(s, "・")
+	label, _, _ = strings.Cut(label, "-")
+	return verbFormMap[label]
+}
 func getEnglishPOS(s string) string {
 	if result, exists := posMap[s]; exists {
 		return result
@@ -932,39 +1028,40 @@ func checkTitle(path, title, test string) bool {
 	return title == fmt.Sprintf("[%s](%s\\%s.md)\n", test, path, test)
 }
 
-// IntakeContent loads and processes text files from the 'New Content' directory
-func intakeContent() map[string]string {
-	output := make(map[string]string)
+// IntakeContent loads and processes every source in the 'New Content' directory and its subfolders
+func intakeContent() map[string][]content_reader.Segment {
+	output := make(map[string][]content_reader.Segment)
 
-	files, err := filepath.Glob(filepath.Join(newContentPath, "*.txt"))
-	if err != nil || len(files) == 0 {
-		fmt.Printf("No new sources found. Place .txt files in %s to begin\n", newContentPath)
+	files := findSources(newContentPath)
+	if len(files) == 0 {
+		fmt.Printf("No new sources found. Place .txt, .srt, .vtt, .ass, .lrc, .html, .epub or manga OCR files in %s to begin\n", newContentPath)
 		return nil
 	}
 
-	for _, txtFile := range files {
-		name := replaceSpaces(strings.TrimSuffix(filepath.Base(txtFile), ".txt"))
-
-		lines, err := readLines(txtFile)
+	for _, file := range files {
+		name := sourceName(newContentPath, file)
+		segments, blob, err := readSource(file)
 		if err != nil {
-			fmt.Printf("Error reading file %s: %v\n", txtFile, err)
+			fmt.Printf("Error reading file %s: %v\n", file, err)
 			continue
 		}
-		blob := ""
-		for _, line := range lines {
-			// Remove Latin characters and spaces
-			re := regexp.MustCompile(`[A-Za-z0-9]`)
-			temp := re.ReplaceAllString(line, "")
-			temp = strings.ReplaceAll(temp, " ", "")
-			if temp != "" {
-				blob += temp + "\n"
-			}
-		}
 
-		output[name] = blob
+		output[name] = segments
+		sourceMeta[name] = loadSourceMeta(newContentPath, file)
 
 		thisContentMd := filepath.Join(contentPath, name+".md")
-		err = os.WriteFile(thisContentMd, []byte(blob), 0644)
+		// Processing a source again keeps the day it was first processed
+		processed := time.Now().Format(dateLayout)
+		if lines, err := readLines(thisContentMd); err == nil && processedDate(lines) != "" {
+			processed = processedDate(lines)
+		}
+
+		note := metaHeader(sourceMeta[name], processed) + blob
+		if furigana := contentFurigana(blob); furigana != "" {
+			note += "\nFurigana\n" + furigana + "\n"
+		}
+
+		err = os.WriteFile(thisContentMd, []byte(note), 0644)
 		if err != nil {
 			fmt.Printf("Error writing to %s: %v\n", thisContentMd, err)
 		}
@@ -973,48 +1070,117 @@ func intakeContent() map[string]string {
 	return output
 }
 
+// ReadSource reads a source into cleaned segments, and the text as the source wrote it for its Content note
+func readSource(file string) ([]content_reader.Segment, string, error) {
+	var read []content_reader.Segment
+	info, err := os.Stat(file)
+	if err == nil && info.IsDir() {
+		read, err = content_reader.ReadMokuroDir(file)
+	} else if err == nil {
+		var data []byte
+		data, err = os.ReadFile(file)
+		if err == nil {
+			read, err = content_reader.Read(file, data)
+		}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	blob := ""
+	var segments []content_reader.Segment
+	lyrics := isLyrics(file)
+	for _, segment := range read {
+		segment.Lyric = lyrics
+		// The Content note keeps the text as the source wrote it, English lines and all
+		blob += segment.Text + "\n"
+		var cleaned []string
+		for _, line := range strings.Split(segment.Text, "\n") {
+			if lyrics && !hasJapanese(line) {
+				continue
+			}
+			temp := normalizeLine(line)
+			if temp != "" {
+				cleaned = append(cleaned, temp)
+			}
+		}
+		if len(cleaned) == 0 {
+			continue
+		}
+		segment.Text = strings.Join(cleaned, "\n")
+		segments = append(segments, segment)
+	}
+	return segments, blob, nil
+}
+
 // GetSentences extracts sentences from processed content
-func getSentences() map[string][]string {
-	punctuation := []string{"\n", ".", "?", "!", "〪", "。", "〭", "！", "．", "？"}
+func getSentences() map[string][]Sentence {
 	sources := intakeContent()
-	output := make(map[string][]string)
+	output := make(map[string][]Sentence)
 
 	if sources == nil {
 		return output
 	}
 
 	for name := range sources {
-		output[name] = []string{}
+		output[name] = []Sentence{}
 	}
 
-	for name, content := range sources {
-		sentence := ""
-		for _, char := range content {
-			isPunctuation := false
-			for _, p := range punctuation {
-				if string(char) == p {
-					isPunctuation = true
-					break
-				}
-			}
-
-			if !isPunctuation {
-				sentence += string(char)
-			} else {
-				if sentence != "" {
-					output[name] = append(output[name], sentence)
-					sentence = ""
+	for name, segments := range sources {
+		// Where each lyric line first came up, so a repeated chorus is counted instead of added again
+		// Manga blocks are kept like prose, every page a line is on gets linked from the Content note
+		seen := map[string]int{}
+		for _, segment := range segments {
+			for _, text := range splitSegment(segment) {
+				if i, exists := seen[text]; exists && segment.Lyric {
+					output[name][i].Count++
+					continue
 				}
+				seen[text] = len(output[name])
+				output[name] = append(output[name], Sentence{Text: text, Segment: segment, Count: 1})
 			}
 		}
-		if sentence != "" { // Add remaining content
-			output[name] = append(output[name], sentence)
-		}
 	}
 
 	return output
 }
 
+// SplitSegment cuts a segment into sentences the way settings.json asks for its kind of source
+func splitSegment(segment content_reader.Segment) []string {
+	mode := settings.ProseSplit
+	switch {
+	case segment.Lyric:
+		mode = settings.LyricsSplit
+	case segment.Page > 0:
+		mode = settings.MangaSplit
+	case segment.Start != "":
+		mode = settings.SubtitleSplit
+	}
+
+	switch mode {
+	case settings_handler.SplitLine:
+		return sentence_splitter.Lines(segment.Text)
+	case settings_handler.SplitWhole:
+		return sentence_splitter.Lines(strings.ReplaceAll(segment.Text, "\n", ""))
+	default:
+		return sentence_splitter.Sentences(segment.Text)
+	}
+}
+
+// SentenceLocation describes where in its source a sentence was, empty for plain text
+func sentenceLocation(sentence Sentence) string {
+	if sentence.Segment.Page > 0 {
+		return fmt.Sprintf("page %d, block %d", sentence.Segment.Page, sentence.Segment.Block)
+	}
+	if sentence.Segment.Start == "" {
+		return ""
+	}
+	if sentence.Segment.End == "" {
+		return sentence.Segment.Start
+	}
+	return fmt.Sprintf("%s --> %s", sentence.Segment.Start, sentence.Segment.End)
+}
+
 // SentenceToWordString converts a sentence to a string of linked words
 func sentenceToWordString(sentence string) string {
 	// Word punctuation to remove
@@ -1023,6 +1189,17 @@ func sentenceToWordString(sentence string) string {
 	for _, word := range words {
 		switch v := word.(type) {
 		case Word:
+			if v.Pos == "opaque" {
+				// Spaces come back from the join
+				if strings.TrimSpace(v.Word) != "" {
+					tempArray = append(tempArray, v.Word)
+				}
+				continue
+			}
+			if v.Pos == "proper_noun" {
+				tempArray = append(tempArray, fmt.Sprintf("[%s](%s\\%s.md)", v.Word, namesPath, v.DictForm))
+				continue
+			}
 			tempArray = append(tempArray, fmt.Sprintf("[%s](%s\\%s.md)", v.Word, wordsPath, v.DictForm))
 		case Verb:
 			tempArray = append(tempArray, fmt.Sprintf("[%s](%s\\%s.md)", v.Word.Word, wordsPath, v.Word.DictForm))
@@ -1103,7 +1280,7 @@ func appendSentence(sentence string) bool {
 	}
 
 	for _, line := range lines {
-		if checkTitle(sentencesPath, line+"\n", sentence) {
+		if line == sentenceLink(sentence) {
 			return true
 		}
 	}
@@ -1185,62 +1362,19 @@ func appendWord(word string) bool {
 
 // EditKanjiTags adds current content tag to a kanji's metadata
 func editKanjiTags(item string) {
-	path := filepath.Join(kanjiPath, item+".md")
-	lines, err := readLines(path)
-	if err != nil {
-		return
-	}
-	for i, line := range lines {
-		if strings.Contains(line, "Tags: ") {
-			lines[i] = fmt.Sprintf("[%s](%s\\%s.md) ", currentName, contentPath, currentName)
-			break
-		}
-	}
-
-	err = os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
-	if err != nil {
-		fmt.Printf("Error writing to %s: %v\n", path, err)
-	}
+	addContentTags(filepath.Join(kanjiPath, item+".md"))
 }
 
 // EditSentenceTags adds current content tag to a sentence's metadata
 func editSentenceTags(item string) {
-	path := filepath.Join(sentencesPath, item+".md")
-	lines, err := readLines(path)
-	if err != nil {
-		return
-	}
-	for i, line := range lines {
-		if strings.Contains(line, "Tags: ") {
-			lines[i] = fmt.Sprintf("[%s](%s\\%s.md) ", currentName, contentPath, currentName)
-			break
-		}
-	}
-
-	err = os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
-	if err != nil {
-		fmt.Printf("Error writing to %s: %v\n", path, err)
-	}
+	sentenceNotesMu.Lock()
+	defer sentenceNotesMu.Unlock()
+	addContentTags(sentenceNotePath(item))
 }
 
 // EditWordsTags adds current content tag to a word's metadata
 func editWordsTags(item string) {
-	path := filepath.Join(wordsPath, item+".md")
-	lines, err := readLines(path)
-	if err != nil {
-		return
-	}
-	for i, line := range lines {
-		if strings.Contains(line, "Tags: ") {
-			lines[i] = fmt.Sprintf("%s [%s](%s\\%s.md) ", strings.TrimSpace(line), currentName, contentPath, currentName)
-			break
-		}
-	}
-
-	err = os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
-	if err != nil {
-		fmt.Printf("Error writing to %s: %v\n", path, err)
-	}
+	addContentTags(filepath.Join(wordsPath, item+".md"))
 }
 
 // === Data Processing Functions ===
@@ -1281,38 +1415,55 @@ func writeToSentences(lst []string) []string {
 // === Flashcard Creation Functions ===
 
 // SentenceCard generates sentence flashcard markdown file
-func sentenceCard(data SentenceData) {
+func sentenceCard(data SentenceData, sentence Sentence) {
 	content := []string{
 		"TARGET DECK: Sentences",
 		"START",
 		"Basic",
 		sentenceToWordString(data.Sentence),
 		fmt.Sprintf("Back: %s", data.Translation),
-		fmt.Sprintf("Tags: [%s](%s\\%s.md)", currentName, pathing.ContentPath, currentName),
+		sourceFurigana(sentence),
+		"Tags: " + contentTags(),
 		"",
 		"END",
 	}
-	writeCard(strings.Join(content, "\n"), filepath.Join(sentencesPath, data.Sentence+".md"))
+	content = append(content, sentenceMetadata(sentence)...)
+	writeSentenceCard(content, sentence)
 }
 func debugger(a any) {
 	debug.PrintStack() // similar to a breakpoint: prints the stack trace
 	fmt.Println(a)
 }
 
+// SentenceMetadata is what goes under a sentence card: the whole sentence, where in the source it was and how often it came up
+func sentenceMetadata(sentence Sentence) []string {
+	// The file name only has the start of the sentence
+	output := []string{"Sentence: " + sentence.Text}
+	if location := sentenceLocation(sentence); location != "" {
+		output = append(output, fmt.Sprintf("Scene: [%s](%s\\%s.md) %s", currentName, pathing.ContentPath, currentName, location))
+	}
+	if sentence.Count > 1 {
+		output = append(output, fmt.Sprintf("Repeats: %d", sentence.Count))
+	}
+	return output
+}
+
 // SentenceCardSkipped generates sentence flashcard without translation
-func sentenceCardSkipped(sentence string) {
+func sentenceCardSkipped(sentence Sentence) {
 	content := []string{
 		"TARGET DECK: Sentences",
 		"START",
 		"Basic",
-		sentenceToWordString(sentence),
+		sentenceToWordString(sentence.Text),
 		"Back: ",
-		fmt.Sprintf("Tags: [%s](%s\\%s.md)", currentName, pathing.ContentPath, currentName),
+		sourceFurigana(sentence),
+		"Tags: " + contentTags(),
 		"",
 		"END",
 	}
+	content = append(content, sentenceMetadata(sentence)...)
 
-	writeCard(strings.Join(content, "\n"), filepath.Join(sentencesPath, sentence+".md"))
+	writeSentenceCard(content, sentence)
 }
 
 // WordCard generates word flashcard markdown file
@@ -1344,10 +1495,15 @@ func verbCard(data []WordData, verb Verb) {
 		fmt.Sprintf("Back: %s", definitions),
 		augs,
 		readings,
-		fmt.Sprintf("Tags: [%s](%s\\%s.md)", currentName, pathing.ContentPath, currentName),
+		wordFurigana(verb.Word.Word),
+	}
+	content = append(content, exampleLines(verb.Word.DictForm, data)...)
+	content = append(content,
+		"Tags: "+contentTags(),
 		"",
 		"END",
-	}
+	)
+	content = append(content, rankLine(wordRank(verb.Word.DictForm, wordReadings(data)...))...)
 
 	writeCard(strings.Join(content, "\n"), filepath.Join(wordsPath, verb.Word.Word+".md"))
 }
@@ -1371,14 +1527,30 @@ func wordCard(data []WordData) {
 		wordToKanjiString(data[0].Word),
 		fmt.Sprintf("Back: %s", definitions),
 		readings,
-		fmt.Sprintf("Tags: [%s](%s\\%s.md)", currentName, pathing.ContentPath, currentName),
+		wordFurigana(data[0].Word),
+	}
+	content = append(content, exampleLines(data[0].Word, data)...)
+	content = append(content,
+		"Tags: "+contentTags(),
 		"",
 		"END",
-	}
+	)
+	content = append(content, rankLine(wordRank(data[0].Word, wordReadings(data)...))...)
 
 	writeCard(strings.Join(content, "\n"), filepath.Join(wordsPath, data[0].Word+".md"))
 }
 
+// WordReadings lists the readings of a word's dictionary entries
+func wordReadings(data []WordData) []string {
+	var output []string
+	for _, d := range data {
+		if d.Reading != "" && !containsRune(output, d.Reading) {
+			output = append(output, d.Reading)
+		}
+	}
+	return output
+}
+
 func clearDir(dir string) error {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -1406,10 +1578,12 @@ func kanjiCard(data KanjiData) {
 		fmt.Sprintf("Back: %s", data.Keyword),
 		data.Readings,
 		data.Radicals,
-		fmt.Sprintf("Tags: [%s](%s\\%s.md)", currentName, pathing.ContentPath, currentName),
+		"Tags: " + contentTags(),
 		"",
 		"END",
 	}
+	// Kanjidic ranks the 2500 most used kanji
+	content = append(content, kanjiRankLine(data.Freq)...)
 
 	writeCard(strings.Join(content, "\n"), filepath.Join(kanjiPath, data.Kanji+".md"))
 }
@@ -1450,7 +1624,7 @@ func fetchWordData(word string) []WordData {
 func fetchSentenceData(sentence string) SentenceData {
 	return SentenceData{
 		Sentence:    sentence,
-		Translation: "", // currently not going to work until a better source for translations is found
+		Translation: translateSentence(sentence),
 	}
 }
 
@@ -1465,7 +1639,7 @@ func writeCard(content, path string) {
 }
 
 // WriteSentencesToContentMd appends sentence links to content markdown file
-func writeSentencesToContentMd(sentences []string, path string) {
+func writeSentencesToContentMd(sentences []Sentence) {
 	f, err := os.OpenFile(filepath.Join(contentPath, currentName+".md"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		fmt.Printf("Error opening %s: %v\n", filepath.Join(contentPath, currentName+".md"), err)
@@ -1474,71 +1648,113 @@ func writeSentencesToContentMd(sentences []string, path string) {
 	defer f.Close()
 
 	f.WriteString("\n")
+	chapter := ""
 	for _, s := range sentences {
-		f.WriteString(fmt.Sprintf("[%s](%s\\%s.md)\n", s, path, s))
+		if s.Segment.Chapter != chapter {
+			chapter = s.Segment.Chapter
+			f.WriteString(fmt.Sprintf("\n## %s\n", chapter))
+		}
+		line := sentenceLink(s.Text)
+		if location := sentenceLocation(s); location != "" {
+			line += " " + location
+		}
+		if s.Count > 1 {
+			line += fmt.Sprintf(" x%d", s.Count)
+		}
+		f.WriteString(line + "\n")
 	}
 }
 
 // === CSV Export Functions ===
 
-// FilesToFlashcardClass converts markdown flashcard files to Flashcard dictionaries
+// FilesToFlashcardClass converts markdown flashcard files to Flashcard dictionaries, one for every card in a file
 func filesToFlashcardClass(filePaths []string) []FlashcardDict {
 	var output []FlashcardDict
 
 	for _, path := range filePaths {
 		lines, _ := readLines(path)
+		priority := 0
 		for i := range lines {
 			lines[i] = strings.TrimSpace(lines[i])
-		}
-
-		// Find Basic section
-		frontIndex := -1
-		for i, line := range lines {
-			if line == "Basic" {
-				frontIndex = i + 1
-				break
+			for _, prefix := range []string{rankPrefix, kanjiRankPrefix} {
+				if rank, found := strings.CutPrefix(lines[i], prefix); found {
+					priority, _ = strconv.Atoi(rank)
+				}
 			}
 		}
-		if frontIndex == -1 {
-			continue
-		}
 
-		// Find Back section
-		backIndex := -1
-		for i, line := range lines {
-			if strings.HasPrefix(line, "Back:") {
-				backIndex = i
+		// Each card runs from START to END, the line after START is the note type
+		for i := 0; i < len(lines); i++ {
+			if lines[i] != "START" || i+1 >= len(lines) {
+				continue
+			}
+			end := slices.Index(lines[i:], "END")
+			if end == -1 {
 				break
 			}
-		}
-		if backIndex == -1 {
-			continue
-		}
+			card := lines[i+1 : i+end]
+			i += end
 
-		// Find Tags section
-		tagIndex := -1
-		for i, line := range lines {
-			if strings.HasPrefix(line, "Tags:") {
-				tagIndex = i
-				break
+			// Find Tags section
+			tagIndex := slices.IndexFunc(card, func(line string) bool { return strings.HasPrefix(line, "Tags:") })
+			if tagIndex == -1 {
+				continue
 			}
-		}
-		if tagIndex == -1 {
-			continue
-		}
 
-		// Extract content
-		front := strings.Join(lines[frontIndex:backIndex], "\n")
-		back := strings.Join(lines[backIndex:tagIndex], "\n")
-		back = strings.Replace(back, "Back: ", "", 1)
+			switch card[0] {
+			case "Basic":
+				// Find Back section
+				backIndex := slices.IndexFunc(card, func(line string) bool { return strings.HasPrefix(line, "Back:") })
+				if backIndex == -1 || backIndex > tagIndex {
+					continue
+				}
 
-		output = append(output, FlashcardDict{
-			Front: front,
-			Back:  back,
-			Cloze: "", // We're not using cloze in this implementation
-		})
+				// Extract content
+				front := strings.Join(card[1:backIndex], "\n")
+				back := strings.Join(card[backIndex:tagIndex], "\n")
+				back = strings.Replace(back, "Back: ", "", 1)
+
+				output = append(output, FlashcardDict{
+					Front:    front,
+					Back:     back,
+					Priority: priority,
+					Note:     path,
+				})
+			case "Cloze":
+				extraIndex := slices.IndexFunc(card, func(line string) bool { return strings.HasPrefix(line, "Back Extra:") })
+				if extraIndex == -1 || extraIndex > tagIndex {
+					continue
+				}
+
+				cloze := strings.Join(card[1:extraIndex], "\n")
+				cloze = strings.Replace(cloze, "Text: ", "", 1)
+				back := strings.Join(card[extraIndex:tagIndex], "\n")
+				back = strings.Replace(back, "Back Extra: ", "", 1)
+
+				output = append(output, FlashcardDict{
+					Back:     back,
+					Cloze:    cloze,
+					Priority: priority,
+					Note:     path,
+				})
+			}
+		}
 	}
 
+	// The most common words come first so they get studied first, word and kanji ranks aren't on the same scale
+	// so each deck keeps its place and is only sorted within itself
+	decks := map[string]int{}
+	for _, card := range output {
+		if _, exists := decks[filepath.Dir(card.Note)]; !exists {
+			decks[filepath.Dir(card.Note)] = len(decks)
+		}
+	}
+	slices.SortStableFunc(output, func(a, b FlashcardDict) int {
+		if order := cmp.Compare(decks[filepath.Dir(a.Note)], decks[filepath.Dir(b.Note)]); order != 0 {
+			return order
+		}
+		return compareRanks(a.Priority, b.Priority)
+	})
 	return output
 }
 
@@ -1557,7 +1773,7 @@ func flashcardsToCSV(flashcards []FlashcardDict, csvFilePath, clozePath string)
 	defer regularWriter.Flush()
 
 	// Write header
-	err = regularWriter.Write([]string{"Front", "Back"})
+	err = regularWriter.Write([]string{"Front", "Back", "Priority"})
 	if err != nil {
 		fmt.Printf("Error writing to %s: %v\n", csvFilePath, err)
 		return
@@ -1575,7 +1791,7 @@ func flashcardsToCSV(flashcards []FlashcardDict, csvFilePath, clozePath string)
 	defer clozeWriter.Flush()
 
 	// Write header
-	err = clozeWriter.Write([]string{"Cloze", "Back"})
+	err = clozeWriter.Write([]string{"Cloze", "Back", "Priority"})
 	if err != nil {
 		fmt.Printf("Error writing to %s: %v\n", clozePath, err)
 		return
@@ -1583,13 +1799,19 @@ func flashcardsToCSV(flashcards []FlashcardDict, csvFilePath, clozePath string)
 
 	// Write data
 	for _, card := range flashcards {
-		err = regularWriter.Write([]string{card.Front, card.Back})
-		if err != nil {
-			fmt.Printf("Error writing to %s: %v\n", csvFilePath, err)
+		priority := ""
+		if card.Priority > 0 {
+			priority = strconv.Itoa(card.Priority)
+		}
+		if card.Front != "" {
+			err = regularWriter.Write([]string{card.Front, card.Back, priority})
+			if err != nil {
+				fmt.Printf("Error writing to %s: %v\n", csvFilePath, err)
+			}
 		}
 
 		if card.Cloze != "" {
-			err = clozeWriter.Write([]string{card.Cloze, card.Back})
+			err = clozeWriter.Write([]string{card.Cloze, card.Back, priority})
 			if err != nil {
 				fmt.Printf("Error writing to %s: %v\n", clozePath, err)
 			}
@@ -1603,6 +1825,7 @@ func makeCSVs() {
 	inputSentences, _ := filepath.Glob(filepath.Join(sentencesPath, "*.md"))
 	inputWords, _ := filepath.Glob(filepath.Join(wordsPath, "*.md"))
 	inputKanji, _ := filepath.Glob(filepath.Join(kanjiPath, "*.md"))
+	inputNames, _ := filepath.Glob(filepath.Join(namesPath, "*.md"))
 
 	// Process sentences
 	flashcardsToCSV(
@@ -1624,6 +1847,13 @@ func makeCSVs() {
 		filepath.Join(csvPath, "Kanji.csv"),
 		filepath.Join(csvPath, "Kanji_cloze.csv"),
 	)
+
+	// Process names
+	flashcardsToCSV(
+		filesToFlashcardClass(inputNames),
+		filepath.Join(csvPath, "Names.csv"),
+		filepath.Join(csvPath, "Names_cloze.csv"),
+	)
 }
 
 // MakeNotes generates notes from source content
@@ -1634,34 +1864,58 @@ func makeNotes() {
 		fmt.Println("No content to process")
 		return
 	}
+	loadUnknowns()
+	defer saveUnknowns()
+	loadKnown()
+	scanKnownMarks()
+	defer saveKnown()
+	defer saveTranslations()
+	mining := settings.MiningMode == settings_handler.MiningIPlusOne
+	wordKanji := knownWordKanji()
 	for source, sentences := range sentencesBySource {
 		currentName = source
 		appendContent(source)
+		addToSeries()
+
+		sourceReadings = map[string]string{}
+		for _, sentence := range sentences {
+			for _, ruby := range sentence.Segment.Readings {
+				sourceReadings[ruby.Base] = ruby.Reading
+			}
+		}
 
 		var kanjiList []string
 		var wordList []Word
 		var wordListString []string
 		var verbList []Verb
+		var nameList []string
+		report := newContentReport(source)
+		// With i+1 mining only sentences with a target get a card
+		targets := map[string]MiningTarget{}
 
 		// Process sentences
 		for _, sentence := range sentences {
-			fmt.Println(sentence)
-			words := parser(sentence)
-			fmt.Println(words)
+			words := parser(sentence.Text)
+			report.addSentence(sentence.Text, words)
+			if mining {
+				if target, ok := miningTargetOf(sentence.Text, words, wordKanji); ok {
+					targets[sentence.Text] = target
+				}
+			}
 			// Extract kanji
 			for _, word := range words {
 				switch v := word.(type) {
 				case Word:
 					for _, c := range v.DictForm {
 
-						if containsRune(kanjiSet, string(c)) && !containsRune(kanjiList, string(c)) && !containsRune(oldKanji, string(c)) {
+						if containsRune(kanjiSet, string(c)) && !containsRune(kanjiList, string(c)) && !containsRune(oldKanji, string(c)) && !isKnownKanji(string(c)) {
 							kanjiList = append(kanjiList, string(c))
 						}
 					}
 				case Verb:
 					for _, c := range v.Word.DictForm {
 
-						if containsRune(kanjiSet, string(c)) && !containsRune(kanjiList, string(c)) && !containsRune(oldKanji, string(c)) {
+						if containsRune(kanjiSet, string(c)) && !containsRune(kanjiList, string(c)) && !containsRune(oldKanji, string(c)) && !isKnownKanji(string(c)) {
 							kanjiList = append(kanjiList, string(c))
 						}
 					}
@@ -1672,11 +1926,27 @@ func makeNotes() {
 			for _, word := range words {
 				switch v := word.(type) {
 				case Word:
+					if v.Pos == "opaque" || isKnownWord(v.DictForm) {
+						// Sentence notes still link known words, they just don't get cards
+						continue
+					}
+					if v.Unknown {
+						recordUnknown(v, sentence.Text)
+					}
+					if v.Pos == "proper_noun" {
+						if !containsRune(nameList, v.DictForm) && !containsRune(oldNames, v.DictForm) {
+							nameList = append(nameList, v.DictForm)
+						}
+						continue
+					}
 					if !containsRune(wordListString, v.DictForm) && !containsRune(oldWords, v.DictForm) {
 						wordList = append(wordList, v)
 						wordListString = append(wordListString, v.DictForm)
 					}
 				case Verb:
+					if isKnownWord(v.Word.DictForm) {
+						continue
+					}
 					if !containsRune(wordListString, v.Word.DictForm) && !containsRune(oldWords, v.Word.DictForm) {
 						wordList = append(wordList, v.Word)
 						wordListString = append(wordListString, v.Word.DictForm)
@@ -1719,23 +1989,42 @@ func makeNotes() {
 				defer wg.Done()
 
 				wData := fetchWordData(w.DictForm)
+				if w.Unknown {
+					wData = unknownWordData(w)
+				}
 				wordCard(wData)
 				editWordsTags(w.Word)
 			}(w)
 		}
 
+		// Process names
+		for _, n := range nameList {
+			wg.Add(1)
+			go func(n string) {
+				defer wg.Done()
+				nameCard(fetchNameData(n))
+				editNameTags(n)
+			}(n)
+		}
+
 		// Process sentences
 		for _, s := range sentences {
+			target, mined := targets[s.Text]
+			if mining && !mined {
+				continue
+			}
 			wg.Add(1)
-			go func(s string) {
+			go func(s Sentence) {
 				defer wg.Done()
-				if skipSentences {
+				if mining {
+					writeMinedCard(s, target)
+				} else if skipSentences {
 					sentenceCardSkipped(s)
 				} else {
-					sData := fetchSentenceData(s)
-					sentenceCard(sData)
+					sData := fetchSentenceData(s.Text)
+					sentenceCard(sData, s)
 				}
-				editSentenceTags(s)
+				editSentenceTags(s.Text)
 			}(s)
 		}
 
@@ -1744,26 +2033,54 @@ func makeNotes() {
 		// Update old lists
 		oldKanji = append(oldKanji, kanjiList...)
 		oldWords = append(oldWords, wordListString...)
+		oldNames = append(oldNames, nameList...)
 
 		// Add new entries to index files
 		var kanjiEntries []string
 		var wordEntries []string
+		var nameEntries []string
 		var sentenceEntries []string
 
+		// Index entries go most common first
+		slices.SortStableFunc(kanjiList, func(a, b string) int {
+			return compareRanks(KanjiLookup(a).Freq, KanjiLookup(b).Freq)
+		})
+		// Ranked the same way as their cards, by their readings
+		ranks := map[string]int{}
+		for _, w := range wordList {
+			wData := fetchWordData(w.DictForm)
+			if w.Unknown {
+				wData = unknownWordData(w)
+			}
+			ranks[w.DictForm] = wordRank(wData[0].Word, wordReadings(wData)...)
+		}
+		slices.SortStableFunc(wordList, func(a, b Word) int {
+			return compareRanks(ranks[a.DictForm], ranks[b.DictForm])
+		})
+
 		for _, k := range kanjiList {
 			kanjiEntries = append(kanjiEntries, fmt.Sprintf("[%s](%s\\%s.md)\n", k, kanjiPath, k))
 		}
 
 		for _, w := range wordList {
-			wordEntries = append(wordEntries, fmt.Sprintf("[%s](%s\\%s.md)\n", w, wordsPath, w))
+			wordEntries = append(wordEntries, fmt.Sprintf("[%s](%s\\%s.md)\n", w.DictForm, wordsPath, w.DictForm))
+		}
+
+		for _, n := range nameList {
+			nameEntries = append(nameEntries, fmt.Sprintf("[%s](%s\\%s.md)\n", n, namesPath, n))
 		}
 
 		for _, s := range sentences {
-			sentenceEntries = append(sentenceEntries, fmt.Sprintf("[%s](%s\\%s.md)\n", s, sentencesPath, s))
+			if _, mined := targets[s.Text]; mining && !mined {
+				continue
+			}
+			sentenceEntries = append(sentenceEntries, sentenceLink(s.Text)+"\n")
 		}
 
 		addNewStuff(kanjiEntries, wordEntries, sentenceEntries)
-		writeSentencesToContentMd(sentences, pathing.SentencesPath)
+		addNewNames(nameEntries)
+		writeContentReport(report)
+		writeSentencesToContentMd(sentences)
 	}
 }
 
@@ -1771,12 +2088,17 @@ func makeNotes() {
 
 // AskForTranslations prompts user about including sentence translations
 func askForTranslations(n int) {
-	fmt.Println("This program uses a translation service for sentence translations.")
+	if sentenceTranslator == nil {
+		fmt.Println("No translator is set in settings.json, sentence cards will be made without translations.")
+		skipSentences = true
+		ready(n)
+		return
+	}
+	fmt.Printf("Sentence translations come from the %s translator in settings.json, saved ones are reused.\n", settings.Translator)
 	fmt.Println("Include sentence translations? (Y/N)")
 
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		answer := strings.ToLower(scanner.Text())
+	for stdin.Scan() {
+		answer := strings.ToLower(stdin.Text())
 		if answer == "y" {
 			fmt.Println("Including sentence translations.")
 			ready(n)
@@ -1795,9 +2117,8 @@ func askForTranslations(n int) {
 func askForCSVs() {
 	fmt.Println("Generate CSV files for Anki? (Y/N)")
 
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		answer := strings.ToLower(scanner.Text())
+	for stdin.Scan() {
+		answer := strings.ToLower(stdin.Text())
 		if answer == "y" {
 			justCSVs()
 			return
@@ -1813,9 +2134,8 @@ func askForCSVs() {
 func justCSVs() {
 	fmt.Println("Generate only CSV files (no new notes)? (Y/N)")
 
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		answer := strings.ToLower(scanner.Text())
+	for stdin.Scan() {
+		answer := strings.ToLower(stdin.Text())
 		if answer == "y" {
 			ready(2)
 			return
@@ -1837,9 +2157,8 @@ func ready(n int) {
 	fmt.Println(messages[n])
 	fmt.Print("Confirm (Y/N): ")
 
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		confirm := strings.ToLower(scanner.Text())
+	for stdin.Scan() {
+		confirm := strings.ToLower(stdin.Text())
 		if confirm == "y" {
 			if n == 0 || n == 1 {
 				makeNotes()
@@ -1876,9 +2195,10 @@ func buildKanjiIndex(kd Kanjidic2) map[string]KanjiData {
 
 		}
 		// use the first stroke count if multiple provided
+		misc := char.getMisc()
 		strokes := 0
-		if len(char.Misc.StrokeCount) > 0 {
-			strokes = char.Misc.StrokeCount[0]
+		if len(misc.StrokeCount) > 0 {
+			strokes = misc.StrokeCount[0]
 		}
 		if len(meanings) == 0 {
 			meanings = append(meanings, "")
@@ -1890,6 +2210,9 @@ func buildKanjiIndex(kd Kanjidic2) map[string]KanjiData {
 			Readings: fmt.Sprintf("%v|%v", on, kun),
 			Strokes:  strokes,
 			Radicals: "", // Kanjidic2 doesn’t include radicals by default
+			Freq:     misc.Freq,
+			JLPT:     misc.JLPT,
+			Grade:    misc.Grade,
 		}
 	}
 	return idx
@@ -1904,10 +2227,12 @@ func buildWordIndex(jd JMdict) map[string][]WordData {
 		}
 		// collect senses
 		var glosses []string
+		var examples []ExamplePair
 		for _, sense := range entry.Sense {
 			for _, g := range sense.Gloss {
 				glosses = append(glosses, g.Text)
 			}
+			examples = append(examples, jmdictExamples(sense.Example)...)
 		}
 		temp := ""
 		for _, r := range readings {
@@ -1922,6 +2247,7 @@ func buildWordIndex(jd JMdict) map[string][]WordData {
 				Word:        k.Keb,
 				Definitions: fmt.Sprintf("%v", glosses),
 				Reading:     temp, // pick the first reading
+				Examples:    examples,
 			})
 		}
 		for _, r := range entry.REle {
@@ -1931,6 +2257,7 @@ func buildWordIndex(jd JMdict) map[string][]WordData {
 					Word:        r.Reb,
 					Definitions: fmt.Sprintf("%v", glosses),
 					Reading:     r.Reb,
+					Examples:    examples,
 				})
 			}
 		}
@@ -1957,17 +2284,62 @@ func WordLookup(s string) []WordData {
 
 // Main is the entry point of the application
 func main() {
+	settings = settings_handler.LoadSettings("settings.json")
+	loadUserDic()
 
 	var kanjidic Kanjidic2
 	if err := xml.Unmarshal(kanjiDic, &kanjidic); err != nil {
 		panic(err)
 	}
+	if settings.KanjidicPath != "" {
+		full, err := loadKanjidic(settings.KanjidicPath)
+		if err != nil {
+			fmt.Printf("Error loading %s, using the bundled kanjidic2.xml: %v\n", settings.KanjidicPath, err)
+		} else {
+			kanjidic = full
+		}
+	}
 	var jmDict JMdict
 	if err := xml.Unmarshal(jmDictData, &jmDict); err != nil {
 		panic(err)
 	}
+	if settings.JMdictPath != "" {
+		full, err := loadJMdict(settings.JMdictPath)
+		if err != nil {
+			fmt.Printf("Error loading %s, using the bundled JMdict_e.xml: %v\n", settings.JMdictPath, err)
+		} else {
+			jmDict = full
+		}
+	}
 	kanjiIdx = buildKanjiIndex(kanjidic)
 	wordIdx = buildWordIndex(jmDict)
+	if settings.JLPTPath != "" {
+		if err := loadJLPT(settings.JLPTPath); err != nil {
+			fmt.Printf("Error loading %s, word levels will be guessed from their kanji: %v\n", settings.JLPTPath, err)
+		}
+	}
+	if settings.FrequencyPath != "" {
+		if err := loadFrequency(settings.FrequencyPath); err != nil {
+			fmt.Printf("Error loading %s, words won't be ranked: %v\n", settings.FrequencyPath, err)
+		}
+	}
+	if settings.TatoebaPath != "" && settings.ExamplesPerWord > 0 {
+		if err := loadTatoeba(settings.TatoebaPath); err != nil {
+			fmt.Printf("Error loading %s, word cards will only get JMdict examples: %v\n", settings.TatoebaPath, err)
+		}
+	}
+	if settings.JMnedictPath != "" {
+		jmnedict, err := loadJMnedict(settings.JMnedictPath)
+		if err != nil {
+			fmt.Printf("Error loading %s, names will use tokenizer readings: %v\n", settings.JMnedictPath, err)
+		} else {
+			nameIdx = buildNameIndex(jmnedict)
+		}
+	}
+	if runCommand(os.Args[1:]) {
+		return
+	}
+	loadTranslator()
 	/* test := "書かせられなければ、食べさせてやり、来させようとしたが、できずに来いと言われ、してしまった。"
 		again := `夢のつづき追いかけていたはずなのに
 	曲がりくねった細い道 人につまずく
@@ -2066,10 +2438,23 @@ func main() {
 	addField("KanjiMd", pathing.KanjiMd)
 	addField("SentencesMd", pathing.SentencesMd)
 	addField("WordsMd", pathing.WordsMd)
+	addField("NamesMd", pathing.NamesMd)
+	addField("UnknownMd", pathing.UnknownMd)
+	addField("UnknownJson", pathing.UnknownJson)
+	addField("KnownJson", pathing.KnownJson)
+	addField("StatsMd", pathing.StatsMd)
+	addField("StatsJson", pathing.StatsJson)
+	addField("CoverageMd", pathing.CoverageMd)
+	addField("ReviewJson", pathing.ReviewJson)
+	addField("TranslationsJson", pathing.TranslationsJson)
+	addField("UserDic", pathing.UserDic)
 	addField("ContentPath", pathing.ContentPath)
 	addField("KanjiPath", pathing.KanjiPath)
 	addField("SentencesPath", pathing.SentencesPath)
 	addField("WordsPath", pathing.WordsPath)
+	addField("NamesPath", pathing.NamesPath)
+	addField("SeriesPath", pathing.SeriesPath)
+	addField("CoveragePath", pathing.CoveragePath)
 	addField("CsvPath", pathing.CsvPath)
 	addField("NewContent", pathing.NewContent)
 
@@ -2088,17 +2473,30 @@ func main() {
 	saveButton := widgets.NewQPushButton2("Save", nil)
 	saveButton.ConnectClicked(func(bool) {
 		newPathing := path_handler.Pathing{
-			NotesDir:      fields["NotesDir"].Text(),
-			ContentMd:     fields["ContentMd"].Text(),
-			KanjiMd:       fields["KanjiMd"].Text(),
-			SentencesMd:   fields["SentencesMd"].Text(),
-			WordsMd:       fields["WordsMd"].Text(),
-			ContentPath:   fields["ContentPath"].Text(),
-			KanjiPath:     fields["KanjiPath"].Text(),
-			SentencesPath: fields["SentencesPath"].Text(),
-			WordsPath:     fields["WordsPath"].Text(),
-			CsvPath:       fields["CsvPath"].Text(),
-			NewContent:    fields["NewContent"].Text(),
+			NotesDir:         fields["NotesDir"].Text(),
+			ContentMd:        fields["ContentMd"].Text(),
+			KanjiMd:          fields["KanjiMd"].Text(),
+			SentencesMd:      fields["SentencesMd"].Text(),
+			WordsMd:          fields["WordsMd"].Text(),
+			NamesMd:          fields["NamesMd"].Text(),
+			UnknownMd:        fields["UnknownMd"].Text(),
+			UnknownJson:      fields["UnknownJson"].Text(),
+			KnownJson:        fields["KnownJson"].Text(),
+			StatsMd:          fields["StatsMd"].Text(),
+			StatsJson:        fields["StatsJson"].Text(),
+			CoverageMd:       fields["CoverageMd"].Text(),
+			ReviewJson:       fields["ReviewJson"].Text(),
+			TranslationsJson: fields["TranslationsJson"].Text(),
+			UserDic:          fields["UserDic"].Text(),
+			ContentPath:      fields["ContentPath"].Text(),
+			KanjiPath:        fields["KanjiPath"].Text(),
+			SentencesPath:    fields["SentencesPath"].Text(),
+			WordsPath:        fields["WordsPath"].Text(),
+			NamesPath:        fields["NamesPath"].Text(),
+			SeriesPath:       fields["SeriesPath"].Text(),
+			CoveragePath:     fields["CoveragePath"].Text(),
+			CsvPath:          fields["CsvPath"].Text(),
+			NewContent:       fields["NewContent"].Text(),
 		}
 
 		file, err := os.Create("pathing.json")
@@ -2134,10 +2532,23 @@ func main() {
 		fields["KanjiMd"].SetText(p.KanjiMd)
 		fields["SentencesMd"].SetText(p.SentencesMd)
 		fields["WordsMd"].SetText(p.WordsMd)
+		fields["NamesMd"].SetText(p.NamesMd)
+		fields["UnknownMd"].SetText(p.UnknownMd)
+		fields["UnknownJson"].SetText(p.UnknownJson)
+		fields["KnownJson"].SetText(p.KnownJson)
+		fields["StatsMd"].SetText(p.StatsMd)
+		fields["StatsJson"].SetText(p.StatsJson)
+		fields["CoverageMd"].SetText(p.CoverageMd)
+		fields["ReviewJson"].SetText(p.ReviewJson)
+		fields["TranslationsJson"].SetText(p.TranslationsJson)
+		fields["UserDic"].SetText(p.UserDic)
 		fields["ContentPath"].SetText(p.ContentPath)
 		fields["KanjiPath"].SetText(p.KanjiPath)
 		fields["SentencesPath"].SetText(p.SentencesPath)
 		fields["WordsPath"].SetText(p.WordsPath)
+		fields["NamesPath"].SetText(p.NamesPath)
+		fields["SeriesPath"].SetText(p.SeriesPath)
+		fields["CoveragePath"].SetText(p.CoveragePath)
 		fields["CsvPath"].SetText(p.CsvPath)
 		fields["NewContent"].SetText(p.NewContent)
 	})
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// MiningTarget is the one item in a sentence that isn't known yet, the part its cloze card hides
+type MiningTarget struct {
+	Kind    string // word or kanji
+	Item    string // The dictionary form, or the kanji itself
+	Surface string // How it's written in the sentence
+}
+
+// Grammar, names and anything that isn't Japanese never count against a sentence
+var miningSkipped = []string{"particle", "auxiliary_verb", "symbol", "opaque", "proper_noun"}
+
+// KnownWordKanji counts the known words each kanji is written in, those kanji count as known even without a kanji card
+func knownWordKanji() map[string]int {
+	output := map[string]int{}
+	for word, item := range knownStore.Words {
+		if !knownEnough(item) {
+			continue
+		}
+		for _, c := range word {
+			output[string(c)]++
+		}
+	}
+	return output
+}
+
+// MiningTargetOf finds a sentence's only unknown word, or when every word is known its only unknown kanji
+// For the kanji, the known words in the sentence don't count, a kanji only they write is what the sentence teaches
+// A sentence with nothing new or more than one new thing has no target
+func miningTargetOf(sentence string, words []any, wordKanji map[string]int) (MiningTarget, bool) {
+	var parsed []Word
+	for _, word := range words {
+		switch v := word.(type) {
+		case Word:
+			parsed = append(parsed, v)
+		case Verb:
+			parsed = append(parsed, v.Word)
+		}
+	}
+	parsed = slices.DeleteFunc(parsed, func(w Word) bool { return w.DictForm == "" || slices.Contains(miningSkipped, w.Pos) })
+
+	// How many of the known words writing each kanji are in this sentence
+	inSentence := map[string]int{}
+	seen := map[string]bool{}
+	for _, w := range parsed {
+		if isKnownWord(w.DictForm) && !seen[w.DictForm] {
+			seen[w.DictForm] = true
+			for _, c := range w.DictForm {
+				inSentence[string(c)]++
+			}
+		}
+	}
+
+	var unknownWords []MiningTarget
+	var unknownKanji, newKanji []string
+	for _, w := range parsed {
+		for _, c := range w.Word {
+			kanji := string(c)
+			if !isKanjiChar(kanji) || isKnownKanji(kanji) {
+				continue
+			}
+			if wordKanji[kanji] == 0 && !containsRune(unknownKanji, kanji) {
+				unknownKanji = append(unknownKanji, kanji)
+			}
+			if wordKanji[kanji] <= inSentence[kanji] && !containsRune(newKanji, kanji) {
+				newKanji = append(newKanji, kanji)
+			}
+		}
+		if isKnownWord(w.DictForm) || slices.ContainsFunc(unknownWords, func(t MiningTarget) bool { return t.Item == w.DictForm }) {
+			continue
+		}
+		unknownWords = append(unknownWords, MiningTarget{Kind: "word", Item: w.DictForm, Surface: w.Word})
+	}
+
+	var target MiningTarget
+	switch {
+	case len(unknownWords) == 1:
+		target = unknownWords[0]
+		// The new word's own kanji are part of learning it, any other new kanji is a second new thing
+		for _, kanji := range unknownKanji {
+			if !strings.Contains(target.Surface, kanji) && !strings.Contains(target.Item, kanji) {
+				return MiningTarget{}, false
+			}
+		}
+	case len(unknownWords) == 0 && len(newKanji) == 1:
+		target = MiningTarget{Kind: "kanji", Item: newKanji[0], Surface: newKanji[0]}
+	default:
+		return MiningTarget{}, false
+	}
+	if _, ok := clozeText(sentence, target); !ok {
+		return MiningTarget{}, false
+	}
+	return target, true
+}
+
+// ClozeText hides the first place the target is written in a sentence
+// 猫が好きです。 => 猫が{{c1::好き}}です。
+func clozeText(sentence string, target MiningTarget) (string, bool) {
+	for _, surface := range []string{target.Surface, target.Item} {
+		if i := strings.Index(sentence, surface); surface != "" && i >= 0 {
+			return sentence[:i] + "{{c1::" + surface + "}}" + sentence[i+len(surface):], true
+		}
+	}
+	return "", false
+}
+
+// TargetLink links the target's word or kanji note
+func targetLink(target MiningTarget) string {
+	if target.Kind == "kanji" {
+		return fmt.Sprintf("[%s](%s\\%s.md)", target.Item, kanjiPath, target.Item)
+	}
+	return fmt.Sprintf("[%s](%s\\%s.md)", target.Item, wordsPath, target.Item)
+}
+
+// ClozeBlock is a cloze card for a sentence with its target hidden, the back links the target's note
+func clozeBlock(sentence string, target MiningTarget, tags string) []string {
+	cloze, _ := clozeText(sentence, target)
+	return []string{
+		"START",
+		"Cloze",
+		"Text: " + cloze,
+		"Back Extra: " + targetLink(target),
+		"Tags: " + tags,
+		"",
+		"END",
+	}
+}
+
+// InsertCardBlock puts a card after the last card already in a note, ahead of the metadata lines
+func insertCardBlock(lines, block []string) []string {
+	at := len(lines)
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "END" {
+			at = i + 1
+		}
+	}
+	return slices.Concat(lines[:at], block, lines[at:])
+}
+
+// WriteMinedCard gives a sentence's note a cloze card on its target, a note that's already there keeps its cards and gets the cloze added
+// False means the note already had a cloze card
+func writeMinedCard(sentence Sentence, target MiningTarget) bool {
+	sentenceNotesMu.Lock()
+	defer sentenceNotesMu.Unlock()
+
+	path := sentenceNotePath(sentence.Text)
+	if _, err := os.Stat(path); err != nil {
+		content := append([]string{"TARGET DECK: Sentences"}, clozeBlock(sentence.Text, target, contentTags())...)
+		content = append(content, sentenceMetadata(sentence)...)
+		content = append(content, "Target: "+target.Item)
+		writeCard(strings.Join(content, "\n"), path)
+		return true
+	}
+
+	lines, err := readLines(path)
+	if err != nil {
+		return false
+	}
+	if containsRune(lines, "Cloze") {
+		return false
+	}
+	// Vault notes keep the tags they were given, the current source's are added after
+	// An external example's tag stays on its own card, the cloze is the content's
+	tags := contentTags()
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "Tags: ") {
+			fields := slices.DeleteFunc(strings.Fields(line)[1:], func(tag string) bool { return tag == externalExampleTag })
+			if len(fields) > 0 || currentName == "" {
+				tags = strings.Join(fields, " ")
+			}
+			break
+		}
+	}
+	lines = insertCardBlock(lines, clozeBlock(sentence.Text, target, tags))
+	for _, line := range append(sentenceMetadata(sentence), "Target: "+target.Item) {
+		if !containsRune(lines, line) {
+			lines = append(lines, line)
+		}
+	}
+	err = os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
+	if err != nil {
+		fmt.Printf("Error writing to %s: %v\n", path, err)
+		return false
+	}
+	if currentName != "" {
+		addContentTags(path)
+	}
+	return true
+}
+
+// MineCommand makes cloze cards for every i+1 sentence in the vault's sentence notes and in New Content
+//
+//	mine [vault|new]
+func mineCommand(args []string) {
+	where := ""
+	if len(args) > 0 {
+		where = args[0]
+	}
+	if where != "" && where != "vault" && where != "new" {
+		fmt.Println("Usage: mine [vault|new]")
+		return
+	}
+	loadKnown()
+	scanKnownMarks()
+	defer saveKnown()
+	wordKanji := knownWordKanji()
+
+	mined := 0
+	mine := func(sentence Sentence) {
+		target, ok := miningTargetOf(sentence.Text, parser(sentence.Text), wordKanji)
+		if ok && writeMinedCard(sentence, target) {
+			fmt.Printf("%s: %s\n", target.Item, sentence.Text)
+			mined++
+		}
+	}
+
+	if where != "new" {
+		currentName = ""
+		filepath.WalkDir(sentencesPath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".md" {
+				return nil
+			}
+			lines, err := readLines(path)
+			if err != nil || containsRune(lines, "Cloze") {
+				return nil
+			}
+			// Notes from before sentenceFileName are named after the whole sentence
+			text := strings.TrimSuffix(filepath.Base(path), ".md")
+			for _, line := range lines {
+				if strings.HasPrefix(line, "Sentence: ") {
+					text = strings.TrimPrefix(line, "Sentence: ")
+				}
+			}
+			if sentenceNotePath(text) != path {
+				fmt.Printf("Skipping %s, run migrate-sentences first\n", path)
+				return nil
+			}
+			mine(Sentence{Text: text})
+			return nil
+		})
+	}
+
+	if where != "vault" {
+		for _, file := range findSources(newContentPath) {
+			segments, _, err := readSource(file)
+			if err != nil {
+				fmt.Printf("Error reading file %s: %v\n", file, err)
+				continue
+			}
+			currentName = sourceName(newContentPath, file)
+			sourceMeta[currentName] = loadSourceMeta(newContentPath, file)
+			for _, segment := range segments {
+				for _, text := range splitSegment(segment) {
+					mine(Sentence{Text: text, Segment: segment, Count: 1})
+				}
+			}
+		}
+	}
+	fmt.Printf("Made %d cloze cards\n", mined)
+}
@@ -0,0 +1,112 @@
+package main
+
+import "testing"
+
+// useKnown swaps in a known store for one test
+func useKnown(t *testing.T, words, kanji []string) {
+	t.Helper()
+	old := knownStore
+	knownStore = KnownStore{Words: map[string]KnownItem{}, Kanji: map[string]KnownItem{}}
+	for _, w := range words {
+		knownStore.Words[w] = KnownItem{Source: KnownFromList}
+	}
+	for _, k := range kanji {
+		knownStore.Kanji[k] = KnownItem{Source: KnownFromList}
+	}
+	t.Cleanup(func() { knownStore = old })
+}
+
+func TestMiningTargetOf(t *testing.T) {
+	useKnown(t, []string{"猫", "猫舌", "鼻血", "好き", "です"}, []string{"好"})
+	wordKanji := knownWordKanji()
+	word := func(surface, dictForm string) Word {
+		return Word{Pos: "noun", DictForm: dictForm, Word: surface}
+	}
+	particle := Word{Pos: "particle", DictForm: "が", Word: "が"}
+
+	tests := []struct {
+		name     string
+		sentence string
+		words    []any
+		want     MiningTarget
+		wantOK   bool
+	}{
+		{
+			"nothing new",
+			"猫が好きです。",
+			[]any{word("猫", "猫"), particle, word("好き", "好き"), word("です", "です")},
+			MiningTarget{}, false,
+		},
+		{
+			"one word",
+			"犬が好きです。",
+			[]any{word("犬", "犬"), particle, word("好き", "好き"), word("です", "です")},
+			MiningTarget{Kind: "word", Item: "犬", Surface: "犬"}, true,
+		},
+		{
+			"a verb is hidden as it's written",
+			"猫が寝た。",
+			[]any{word("猫", "猫"), particle, Verb{Word: Word{Pos: "verb", DictForm: "寝る", Word: "寝た"}}},
+			MiningTarget{Kind: "word", Item: "寝る", Surface: "寝た"}, true,
+		},
+		{
+			"a known word's kanji don't count against a new word",
+			"猫舌が犬。",
+			[]any{word("猫舌", "猫舌"), particle, word("犬", "犬")},
+			MiningTarget{Kind: "word", Item: "犬", Surface: "犬"}, true,
+		},
+		{
+			"one kanji only a known word in the sentence writes",
+			"猫舌です。",
+			[]any{word("猫舌", "猫舌"), word("です", "です")},
+			MiningTarget{Kind: "kanji", Item: "舌", Surface: "舌"}, true,
+		},
+		{
+			"two new kanji",
+			"鼻血です。",
+			[]any{word("鼻血", "鼻血"), word("です", "です")},
+			MiningTarget{}, false,
+		},
+		{
+			"two unknown words",
+			"犬が鳥。",
+			[]any{word("犬", "犬"), particle, word("鳥", "鳥")},
+			MiningTarget{}, false,
+		},
+		{
+			"the target isn't written in the sentence",
+			"猫が好き。",
+			[]any{word("猫", "猫"), particle, word("すき", "隙")},
+			MiningTarget{}, false,
+		},
+	}
+	for _, test := range tests {
+		got, ok := miningTargetOf(test.sentence, test.words, wordKanji)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("%s: miningTargetOf = %+v, %v, want %+v, %v", test.name, got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestClozeText(t *testing.T) {
+	tests := []struct {
+		sentence string
+		target   MiningTarget
+		want     string
+		wantOK   bool
+	}{
+		{"猫が好きです。", MiningTarget{Kind: "word", Item: "好き", Surface: "好き"}, "猫が{{c1::好き}}です。", true},
+		{"猫が寝た。", MiningTarget{Kind: "word", Item: "寝る", Surface: "寝た"}, "猫が{{c1::寝た}}。", true},
+		{"寝るか寝ないか", MiningTarget{Kind: "word", Item: "寝る", Surface: "寝な"}, "寝るか{{c1::寝な}}いか", true},
+		{"寝る。", MiningTarget{Kind: "word", Item: "寝る", Surface: "ねる"}, "{{c1::寝る}}。", true},
+		{"猫舌です。", MiningTarget{Kind: "kanji", Item: "舌", Surface: "舌"}, "猫{{c1::舌}}です。", true},
+		{"猫が好き。", MiningTarget{Kind: "word", Item: "隙", Surface: "すき"}, "", false},
+		{"猫が好き。", MiningTarget{}, "", false},
+	}
+	for _, test := range tests {
+		got, ok := clozeText(test.sentence, test.target)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("clozeText(%q, %+v) = %q, %v, want %q, %v", test.sentence, test.target, got, ok, test.want, test.wantOK)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ikawaha/kagome/tokenizer"
+)
+
+// JMnedict is the top level of the JMnedict name dictionary
+type JMnedict struct {
+	XMLName xml.Name    `xml:"JMnedict"`
+	Entries []NameEntry `xml:"entry"`
+}
+
+type NameEntry struct {
+	EntSeq string  `xml:"ent_seq"`
+	KEle   []KEle  `xml:"k_ele"`
+	REle   []REle  `xml:"r_ele"`
+	Trans  []Trans `xml:"trans"`
+}
+
+type Trans struct {
+	NameType []string `xml:"name_type"`
+	Xref     []string `xml:"xref"`
+	TransDet []string `xml:"trans_det"`
+}
+
+type NameData struct {
+	Name         string
+	Reading      string
+	Type         string
+	Translations string
+}
+
+// JMnedict name types grouped into the kinds of name notes we make
+var nameTypeMap = map[string]string{
+	"surname": "person",
+	"given":   "person",
+	"masc":    "person",
+	"fem":     "person",
+	"person":  "person",
+	"char":    "person",
+	"creat":   "person",
+	"dei":     "person",
+	"myth":    "person",
+	"fict":    "person",
+
+	"place":   "place",
+	"station": "place",
+
+	"company":      "organization",
+	"organization": "organization",
+	"group":        "organization",
+	"serv":         "organization",
+
+	"work":    "work",
+	"product": "work",
+	"doc":     "work",
+	"ev":      "work",
+}
+
+// Proper noun subclasses from kagome, used when JMnedict doesn't know the name
+var properNounTypeMap = map[string]string{
+	"人名": "person",
+	"地域": "place",
+	"組織": "organization",
+	"地名": "place",
+}
+
+var nameIdx map[string][]NameData
+
+// LoadJMnedict decodes a local JMnedict.xml, the DTD entities are left as text so we can read the name types
+func loadJMnedict(path string) (JMnedict, error) {
+	var jmnedict JMnedict
+	file, err := os.Open(path)
+	if err != nil {
+		return jmnedict, err
+	}
+	defer file.Close()
+
+	decoder := xml.NewDecoder(file)
+	decoder.Strict = false
+	err = decoder.Decode(&jmnedict)
+	return jmnedict, err
+}
+
+func getNameType(s string) string {
+	s = strings.Trim(s, "&;")
+	if result, exists := nameTypeMap[s]; exists {
+		return result
+	}
+	return "unclassified"
+}
+
+func buildNameIndex(jn JMnedict) map[string][]NameData {
+	idx := make(map[string][]NameData)
+	for _, entry := range jn.Entries {
+		reading := ""
+		if len(entry.REle) > 0 {
+			reading = entry.REle[0].Reb
+		}
+		nameType := "unclassified"
+		var translations []string
+		for _, t := range entry.Trans {
+			if len(t.NameType) > 0 && nameType == "unclassified" {
+				nameType = getNameType(t.NameType[0])
+			}
+			translations = append(translations, t.TransDet...)
+		}
+		data := NameData{
+			Reading:      reading,
+			Type:         nameType,
+			Translations: strings.Join(translations, ", "),
+		}
+		for _, k := range entry.KEle {
+			data.Name = k.Keb
+			idx[k.Keb] = append(idx[k.Keb], data)
+		}
+		if len(entry.KEle) == 0 {
+			for _, r := range entry.REle {
+				data.Name = r.Reb
+				data.Reading = r.Reb
+				idx[r.Reb] = append(idx[r.Reb], data)
+			}
+		}
+	}
+	return idx
+}
+
+func NameLookup(s string) []NameData {
+	if nd, ok := nameIdx[s]; ok {
+		return nd
+	}
+	return []NameData{}
+}
+
+// TokenizerReading strings together kagome's readings for every token in the text
+func tokenizerReading(text string) string {
+	var result strings.Builder
+	for _, token := range tokenize(text) {
+		if token.Class == tokenizer.DUMMY {
+			continue
+		}
+		reading := tokenReading(token)
+		if reading == "" {
+			reading = token.Surface
+		}
+		result.WriteString(reading)
+	}
+	return result.String()
+}
+
+// TokenizerNameType guesses a name type from kagome's proper noun subclass
+func tokenizerNameType(name string) string {
+	for _, token := range tokenize(name) {
+		features := tokenFeatures(token)
+		if len(features) < 3 || features[1] != "固有名詞" {
+			continue
+		}
+		if result, exists := properNounTypeMap[features[2]]; exists {
+			return result
+		}
+	}
+	return "unclassified"
+}
+
+// FetchNameData gets name data from JMnedict, falling back to the tokenizer's reading
+func fetchNameData(name string) NameData {
+	result := NameLookup(name)
+	if len(result) > 0 {
+		return result[0]
+	}
+	return NameData{
+		Name:         name,
+		Reading:      tokenizerReading(name),
+		Type:         tokenizerNameType(name),
+		Translations: "",
+	}
+}
+
+// NameCard generates name flashcard markdown file
+func nameCard(data NameData) {
+	content := []string{
+		"TARGET DECK: Names",
+		"START",
+		"Basic",
+		wordToKanjiString(data.Name),
+		fmt.Sprintf("Back: %s", data.Translations),
+		data.Type,
+		data.Reading,
+		readingFurigana(data.Name, data.Reading),
+		"Tags: " + contentTags(),
+		"",
+		"END",
+	}
+
+	writeCard(strings.Join(content, "\n"), filepath.Join(namesPath, data.Name+".md"))
+}
+
+// EditNameTags adds current content tag to a name's metadata
+func editNameTags(item string) {
+	addContentTags(filepath.Join(namesPath, item+".md"))
+}
+
+// AddNewNames adds new entries to the names index
+func addNewNames(nl []string) {
+	if len(nl) == 0 {
+		return
+	}
+	f, err := os.OpenFile(namesMd, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Printf("Error opening %s: %v\n", namesMd, err)
+		return
+	}
+	defer f.Close()
+	for _, n := range nl {
+		f.WriteString(n)
+	}
+}
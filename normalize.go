@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// NormalizeLine gets a source line ready to be split into sentences, the way settings.json asks
+// An empty result means the line is dropped
+func normalizeLine(line string) string {
+	if settings.NFKC {
+		// NFKC also folds widths: ２ => 2, Ａ => A, ｶﾀｶﾅ => カタカナ
+		line = norm.NFKC.String(line)
+	} else {
+		line = width.Fold.String(line)
+	}
+	if settings.DropNonJapanese && !hasJapanese(line) {
+		return ""
+	}
+
+	var kept []rune
+	for _, r := range line {
+		switch {
+		case isASCIIDigit(r) && !settings.KeepDigits:
+			continue
+		case isASCIILetter(r) && !settings.KeepLatin:
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return dropSpaces(kept)
+}
+
+// DropSpaces takes out the spaces Japanese doesn't need, keeping the ones between Latin words and numbers
+func dropSpaces(runes []rune) string {
+	var result strings.Builder
+	for i, r := range runes {
+		if !unicode.IsSpace(r) {
+			result.WriteRune(r)
+			continue
+		}
+		if i == 0 || i == len(runes)-1 || unicode.IsSpace(runes[i-1]) {
+			continue
+		}
+		if isOpaqueRune(runes[i-1]) && isOpaqueRune(runes[i+1]) {
+			result.WriteRune(' ')
+		}
+	}
+	return result.String()
+}
+
+// IsOpaque reports whether a token is a Latin word or a number, which are kept in the sentence but get no notes
+func isOpaque(surface string) bool {
+	hasAlnum := false
+	for _, r := range surface {
+		if !isOpaqueRune(r) {
+			return false
+		}
+		hasAlnum = hasAlnum || isASCIIDigit(r) || isASCIILetter(r)
+	}
+	return hasAlnum
+}
+
+func isOpaqueRune(r rune) bool {
+	return isASCIIDigit(r) || isASCIILetter(r) || strings.ContainsRune("'-.,&", r)
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
@@ -8,17 +8,30 @@ import (
 )
 
 type Pathing struct {
-	NotesDir      string `json:"notesDir"`
-	ContentMd     string `json:"contentMd"`
-	KanjiMd       string `json:"kanjiMd"`
-	SentencesMd   string `json:"sentencesMd"`
-	WordsMd       string `json:"wordsMd"`
-	ContentPath   string `json:"contentPath"`
-	KanjiPath     string `json:"kanjiPath"`
-	SentencesPath string `json:"sentencesPath"`
-	WordsPath     string `json:"wordsPath"`
-	CsvPath       string `json:"csvPath"`
-	NewContent    string `json:"newContent"`
+	NotesDir         string `json:"notesDir"`
+	ContentMd        string `json:"contentMd"`
+	KanjiMd          string `json:"kanjiMd"`
+	SentencesMd      string `json:"sentencesMd"`
+	WordsMd          string `json:"wordsMd"`
+	NamesMd          string `json:"namesMd"`
+	UnknownMd        string `json:"unknownMd"`
+	UnknownJson      string `json:"unknownJson"`
+	KnownJson        string `json:"knownJson"`
+	StatsMd          string `json:"statsMd"`
+	StatsJson        string `json:"statsJson"`
+	CoverageMd       string `json:"coverageMd"`
+	ReviewJson       string `json:"reviewJson"`
+	TranslationsJson string `json:"translationsJson"`
+	UserDic          string `json:"userDic"`
+	ContentPath      string `json:"contentPath"`
+	KanjiPath        string `json:"kanjiPath"`
+	SentencesPath    string `json:"sentencesPath"`
+	WordsPath        string `json:"wordsPath"`
+	NamesPath        string `json:"namesPath"`
+	SeriesPath       string `json:"seriesPath"`
+	CoveragePath     string `json:"coveragePath"`
+	CsvPath          string `json:"csvPath"`
+	NewContent       string `json:"newContent"`
 }
 
 func DefaultPathing() Pathing {
@@ -29,32 +42,58 @@ func DefaultPathing() Pathing {
 
 	notesDir := filepath.Join(base, filepath.Join("Notes", "Japanese Notes"))
 	return Pathing{
-		NotesDir:      notesDir,
-		ContentMd:     filepath.Join(notesDir, "Content.md"),
-		KanjiMd:       filepath.Join(notesDir, "Kanji.md"),
-		SentencesMd:   filepath.Join(notesDir, "Sentences.md"),
-		WordsMd:       filepath.Join(notesDir, "Words.md"),
-		ContentPath:   filepath.Join(notesDir, "Content"),
-		KanjiPath:     filepath.Join(notesDir, "Kanji"),
-		SentencesPath: filepath.Join(notesDir, "Sentences"),
-		WordsPath:     filepath.Join(notesDir, "Words"),
-		CsvPath:       filepath.Join(notesDir, "CSV"),
-		NewContent:    filepath.Join(notesDir, "New"),
+		NotesDir:         notesDir,
+		ContentMd:        filepath.Join(notesDir, "Content.md"),
+		KanjiMd:          filepath.Join(notesDir, "Kanji.md"),
+		SentencesMd:      filepath.Join(notesDir, "Sentences.md"),
+		WordsMd:          filepath.Join(notesDir, "Words.md"),
+		NamesMd:          filepath.Join(notesDir, "Names.md"),
+		UnknownMd:        filepath.Join(notesDir, "Unknown.md"),
+		UnknownJson:      filepath.Join(notesDir, "Unknown.json"),
+		KnownJson:        filepath.Join(notesDir, "Known.json"),
+		StatsMd:          filepath.Join(notesDir, "Stats.md"),
+		StatsJson:        filepath.Join(notesDir, "Stats.json"),
+		CoverageMd:       filepath.Join(notesDir, "Coverage.md"),
+		ReviewJson:       filepath.Join(notesDir, "Review.json"),
+		TranslationsJson: filepath.Join(notesDir, "Translations.json"),
+		UserDic:          filepath.Join(notesDir, "userdic.txt"),
+		ContentPath:      filepath.Join(notesDir, "Content"),
+		KanjiPath:        filepath.Join(notesDir, "Kanji"),
+		SentencesPath:    filepath.Join(notesDir, "Sentences"),
+		WordsPath:        filepath.Join(notesDir, "Words"),
+		NamesPath:        filepath.Join(notesDir, "Names"),
+		SeriesPath:       filepath.Join(notesDir, "Series"),
+		CoveragePath:     filepath.Join(notesDir, "Coverage"),
+		CsvPath:          filepath.Join(notesDir, "CSV"),
+		NewContent:       filepath.Join(notesDir, "New"),
 	}
 }
 
 var TestPathing = Pathing{
-	NotesDir:      filepath.Join("Test", "Japanese Notes"),
-	ContentMd:     filepath.Join(filepath.Join("Test", "Japanese Notes"), "Content.md"),
-	KanjiMd:       filepath.Join(filepath.Join("Test", "Japanese Notes"), "Kanji.md"),
-	SentencesMd:   filepath.Join(filepath.Join("Test", "Japanese Notes"), "Sentences.md"),
-	WordsMd:       filepath.Join(filepath.Join("Test", "Japanese Notes"), "Words.md"),
-	ContentPath:   filepath.Join(filepath.Join("Test", "Japanese Notes"), "Content"),
-	KanjiPath:     filepath.Join(filepath.Join("Test", "Japanese Notes"), "Kanji"),
-	SentencesPath: filepath.Join(filepath.Join("Test", "Japanese Notes"), "Sentences"),
-	WordsPath:     filepath.Join(filepath.Join("Test", "Japanese Notes"), "Words"),
-	CsvPath:       filepath.Join(filepath.Join("Test", "Japanese Notes"), "CSV"),
-	NewContent:    "./New Content",
+	NotesDir:         filepath.Join("Test", "Japanese Notes"),
+	ContentMd:        filepath.Join(filepath.Join("Test", "Japanese Notes"), "Content.md"),
+	KanjiMd:          filepath.Join(filepath.Join("Test", "Japanese Notes"), "Kanji.md"),
+	SentencesMd:      filepath.Join(filepath.Join("Test", "Japanese Notes"), "Sentences.md"),
+	WordsMd:          filepath.Join(filepath.Join("Test", "Japanese Notes"), "Words.md"),
+	NamesMd:          filepath.Join(filepath.Join("Test", "Japanese Notes"), "Names.md"),
+	UnknownMd:        filepath.Join(filepath.Join("Test", "Japanese Notes"), "Unknown.md"),
+	UnknownJson:      filepath.Join(filepath.Join("Test", "Japanese Notes"), "Unknown.json"),
+	KnownJson:        filepath.Join(filepath.Join("Test", "Japanese Notes"), "Known.json"),
+	StatsMd:          filepath.Join(filepath.Join("Test", "Japanese Notes"), "Stats.md"),
+	StatsJson:        filepath.Join(filepath.Join("Test", "Japanese Notes"), "Stats.json"),
+	CoverageMd:       filepath.Join(filepath.Join("Test", "Japanese Notes"), "Coverage.md"),
+	ReviewJson:       filepath.Join(filepath.Join("Test", "Japanese Notes"), "Review.json"),
+	TranslationsJson: filepath.Join(filepath.Join("Test", "Japanese Notes"), "Translations.json"),
+	UserDic:          filepath.Join(filepath.Join("Test", "Japanese Notes"), "userdic.txt"),
+	ContentPath:      filepath.Join(filepath.Join("Test", "Japanese Notes"), "Content"),
+	KanjiPath:        filepath.Join(filepath.Join("Test", "Japanese Notes"), "Kanji"),
+	SentencesPath:    filepath.Join(filepath.Join("Test", "Japanese Notes"), "Sentences"),
+	WordsPath:        filepath.Join(filepath.Join("Test", "Japanese Notes"), "Words"),
+	NamesPath:        filepath.Join(filepath.Join("Test", "Japanese Notes"), "Names"),
+	SeriesPath:       filepath.Join(filepath.Join("Test", "Japanese Notes"), "Series"),
+	CoveragePath:     filepath.Join(filepath.Join("Test", "Japanese Notes"), "Coverage"),
+	CsvPath:          filepath.Join(filepath.Join("Test", "Japanese Notes"), "CSV"),
+	NewContent:       "./New Content",
 }
 
 func LoadPathing(filePath string) Pathing {
@@ -67,17 +106,30 @@ func LoadPathing(filePath string) Pathing {
 	if err != nil {
 		fmt.Println("Could not open pathing.json, using defaults and creating pathing.json:", err)
 		data := map[string]string{
-			"notesDir":      "Notes/Japanese Notes",
-			"contentMd":     "Notes/Japanese Notes/Content.md",
-			"kanjiMd":       "Notes/Japanese Notes/Kanji.md",
-			"sentencesMd":   "Notes/Japanese Notes/Sentences.md",
-			"wordsMd":       "Notes/Japanese Notes/Words.md",
-			"contentPath":   "Notes/Japanese Notes/Content",
-			"kanjiPath":     "Notes/Japanese Notes/Kanji",
-			"sentencesPath": "Notes/Japanese Notes/Sentences",
-			"wordsPath":     "Notes/Japanese Notes/Words",
-			"csvPath":       "Notes/Japanese Notes/CSV",
-			"newContent":    "Notes/Japanese Notes/New",
+			"notesDir":         "Notes/Japanese Notes",
+			"contentMd":        "Notes/Japanese Notes/Content.md",
+			"kanjiMd":          "Notes/Japanese Notes/Kanji.md",
+			"sentencesMd":      "Notes/Japanese Notes/Sentences.md",
+			"wordsMd":          "Notes/Japanese Notes/Words.md",
+			"namesMd":          "Notes/Japanese Notes/Names.md",
+			"unknownMd":        "Notes/Japanese Notes/Unknown.md",
+			"unknownJson":      "Notes/Japanese Notes/Unknown.json",
+			"knownJson":        "Notes/Japanese Notes/Known.json",
+			"statsMd":          "Notes/Japanese Notes/Stats.md",
+			"statsJson":        "Notes/Japanese Notes/Stats.json",
+			"coverageMd":       "Notes/Japanese Notes/Coverage.md",
+			"reviewJson":       "Notes/Japanese Notes/Review.json",
+			"translationsJson": "Notes/Japanese Notes/Translations.json",
+			"userDic":          "Notes/Japanese Notes/userdic.txt",
+			"contentPath":      "Notes/Japanese Notes/Content",
+			"kanjiPath":        "Notes/Japanese Notes/Kanji",
+			"sentencesPath":    "Notes/Japanese Notes/Sentences",
+			"wordsPath":        "Notes/Japanese Notes/Words",
+			"namesPath":        "Notes/Japanese Notes/Names",
+			"seriesPath":       "Notes/Japanese Notes/Series",
+			"coveragePath":     "Notes/Japanese Notes/Coverage",
+			"csvPath":          "Notes/Japanese Notes/CSV",
+			"newContent":       "Notes/Japanese Notes/New",
 		}
 
 		file, err := os.Create("pathing.json")
@@ -119,6 +171,36 @@ func LoadPathing(filePath string) Pathing {
 	if pathing.WordsMd == "" {
 		pathing.WordsMd = defaults.WordsMd
 	}
+	if pathing.NamesMd == "" {
+		pathing.NamesMd = defaults.NamesMd
+	}
+	if pathing.UnknownMd == "" {
+		pathing.UnknownMd = defaults.UnknownMd
+	}
+	if pathing.UnknownJson == "" {
+		pathing.UnknownJson = defaults.UnknownJson
+	}
+	if pathing.KnownJson == "" {
+		pathing.KnownJson = defaults.KnownJson
+	}
+	if pathing.StatsMd == "" {
+		pathing.StatsMd = defaults.StatsMd
+	}
+	if pathing.StatsJson == "" {
+		pathing.StatsJson = defaults.StatsJson
+	}
+	if pathing.CoverageMd == "" {
+		pathing.CoverageMd = defaults.CoverageMd
+	}
+	if pathing.ReviewJson == "" {
+		pathing.ReviewJson = defaults.ReviewJson
+	}
+	if pathing.TranslationsJson == "" {
+		pathing.TranslationsJson = defaults.TranslationsJson
+	}
+	if pathing.UserDic == "" {
+		pathing.UserDic = defaults.UserDic
+	}
 	if pathing.ContentPath == "" {
 		pathing.ContentPath = defaults.ContentPath
 	}
@@ -131,6 +213,15 @@ func LoadPathing(filePath string) Pathing {
 	if pathing.WordsPath == "" {
 		pathing.WordsPath = defaults.WordsPath
 	}
+	if pathing.NamesPath == "" {
+		pathing.NamesPath = defaults.NamesPath
+	}
+	if pathing.SeriesPath == "" {
+		pathing.SeriesPath = defaults.SeriesPath
+	}
+	if pathing.CoveragePath == "" {
+		pathing.CoveragePath = defaults.CoveragePath
+	}
 	if pathing.CsvPath == "" {
 		pathing.CsvPath = defaults.CsvPath
 	}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ContentReport is how hard a source is going by what's known, for choosing what to read next
+type ContentReport struct {
+	Source        string         `json:"source"`
+	Sentences     int            `json:"sentences"`
+	Tokens        int            `json:"tokens"`
+	KnownTokens   int            `json:"knownTokens"`
+	KnownPercent  float64        `json:"knownPercent"`
+	UniqueWords   int            `json:"uniqueWords"`
+	UnknownWords  int            `json:"unknownWords"`
+	WordJLPT      map[string]int `json:"wordJlpt"`      // Unique words by level
+	WordFrequency map[string]int `json:"wordFrequency"` // Unique words by frequency band
+	UniqueKanji   int            `json:"uniqueKanji"`
+	KnownKanji    int            `json:"knownKanji"`
+	KanjiCoverage float64        `json:"kanjiCoverage"`
+	KanjiJLPT     map[string]int `json:"kanjiJlpt"`
+	AverageChars  float64        `json:"averageSentenceChars"`
+	AverageTokens float64        `json:"averageSentenceTokens"`
+
+	words map[string]bool
+	kanji map[string]bool
+	chars int
+}
+
+// Frequency bands by the highest rank in each, the most common words first
+var frequencyBands = []int{1000, 5000, 10000, 20000}
+
+// FrequencyBand names the band a rank from the frequency list falls in
+func frequencyBand(rank int) string {
+	if rank == 0 {
+		return "unranked"
+	}
+	low := 1
+	for _, high := range frequencyBands {
+		if rank <= high {
+			return fmt.Sprintf("%d-%d", low, high)
+		}
+		low = high + 1
+	}
+	return fmt.Sprintf("%d+", low)
+}
+
+// FrequencyBandOrder is every band in the order they're shown
+func frequencyBandOrder() []string {
+	var output []string
+	for _, high := range frequencyBands {
+		output = append(output, frequencyBand(high))
+	}
+	return append(output, frequencyBand(frequencyBands[len(frequencyBands)-1]+1), frequencyBand(0))
+}
+
+func newContentReport(source string) *ContentReport {
+	return &ContentReport{
+		Source:        source,
+		WordJLPT:      map[string]int{},
+		WordFrequency: map[string]int{},
+		KanjiJLPT:     map[string]int{},
+		words:         map[string]bool{},
+		kanji:         map[string]bool{},
+	}
+}
+
+// AddSentence counts a sentence and the parser's words for it
+func (r *ContentReport) addSentence(sentence string, words []any) {
+	r.Sentences++
+	r.chars += len([]rune(sentence))
+	for _, c := range sentence {
+		if containsRune(kanjiSet, string(c)) {
+			r.kanji[string(c)] = true
+		}
+	}
+
+	for _, word := range words {
+		dictForm := ""
+		switch v := word.(type) {
+		case Word:
+			if v.Pos == "opaque" || v.Pos == "symbol" {
+				continue
+			}
+			dictForm = v.DictForm
+		case Verb:
+			dictForm = v.Word.DictForm
+		}
+		if dictForm == "" {
+			continue
+		}
+		r.Tokens++
+		if isKnownWord(dictForm) {
+			r.KnownTokens++
+		}
+		r.words[dictForm] = true
+	}
+}
+
+// Finish works out the totals once every sentence has been added
+func (r *ContentReport) finish() {
+	r.UniqueWords = len(r.words)
+	for word := range r.words {
+		if !isKnownWord(word) {
+			r.UnknownWords++
+		}
+		r.WordJLPT[wordJLPTLabel(word)]++
+		r.WordFrequency[frequencyBand(wordRank(word))]++
+	}
+	r.UniqueKanji = len(r.kanji)
+	for kanji := range r.kanji {
+		if isKnownKanji(kanji) {
+			r.KnownKanji++
+		}
+		r.KanjiJLPT[kanjiJLPTLabel(kanji)]++
+	}
+	r.KnownPercent = percent(r.KnownTokens, r.Tokens)
+	r.KanjiCoverage = percent(r.KnownKanji, r.UniqueKanji)
+	if r.Sentences > 0 {
+		r.AverageChars = float64(r.chars) / float64(r.Sentences)
+		r.AverageTokens = float64(r.Tokens) / float64(r.Sentences)
+	}
+}
+
+func percent(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(whole)
+}
+
+// Markdown is the Difficulty section of the Content note
+func (r *ContentReport) markdown() string {
+	levels := func(counts map[string]int, order []string) string {
+		var parts []string
+		for _, label := range order {
+			if n := counts[label]; n > 0 {
+				parts = append(parts, fmt.Sprintf("%s %d", label, n))
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	lines := []string{
+		"## Difficulty",
+		fmt.Sprintf("Known tokens: %.1f%% (%d of %d)", r.KnownPercent, r.KnownTokens, r.Tokens),
+		fmt.Sprintf("Unique words: %d, unknown: %d", r.UniqueWords, r.UnknownWords),
+		fmt.Sprintf("Word JLPT: %s", levels(r.WordJLPT, jlptLabels)),
+		fmt.Sprintf("Word frequency: %s", levels(r.WordFrequency, frequencyBandOrder())),
+		fmt.Sprintf("Kanji: %d unique, %d known (%.1f%% coverage)", r.UniqueKanji, r.KnownKanji, r.KanjiCoverage),
+		fmt.Sprintf("Kanji JLPT: %s", levels(r.KanjiJLPT, jlptLabels)),
+		fmt.Sprintf("Average sentence length: %.1f characters, %.1f tokens", r.AverageChars, r.AverageTokens),
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// WriteContentReport adds the report to the current Content note and writes it as JSON next to it
+func writeContentReport(r *ContentReport) {
+	r.finish()
+
+	path := filepath.Join(contentPath, currentName+".md")
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Printf("Error opening %s: %v\n", path, err)
+		return
+	}
+	f.WriteString("\n" + r.markdown())
+	f.Close()
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding report for %s: %v\n", currentName, err)
+		return
+	}
+	jsonPath := filepath.Join(contentPath, currentName+".report.json")
+	if err := os.WriteFile(jsonPath, data, 0644); err != nil {
+		fmt.Printf("Error writing to %s: %v\n", jsonPath, err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// useLevels swaps in kanjidic's old levels, a JLPT list and a frequency list for one test
+func useLevels(t *testing.T) {
+	t.Helper()
+	oldKanji, oldJLPT, oldRanks := kanjiIdx, jlptWords, wordRanks
+	kanjiIdx = map[string]KanjiData{
+		"食": {Kanji: "食", JLPT: 4},
+		"飲": {Kanji: "飲", JLPT: 4},
+		"難": {Kanji: "難", JLPT: 2},
+		"鬱": {Kanji: "鬱"},
+	}
+	jlptWords = map[string]int{"食べる": 5, "難": 3}
+	wordRanks = map[string]int{"食べる": 500, "難しい": 6000, "すごい": 25000}
+	t.Cleanup(func() { kanjiIdx, jlptWords, wordRanks = oldKanji, oldJLPT, oldRanks })
+}
+
+func TestWordJLPT(t *testing.T) {
+	useLevels(t)
+	tests := []struct {
+		word  string
+		level int
+		label string
+	}{
+		{"食べる", 5, "N5"},   // On the list
+		{"難しい", 3, "N3"},   // Its kanji is on the list
+		{"飲む", 0, "Old 4"}, // Only kanjidic knows its kanji
+		{"飲難", 0, "Old 2"}, // The hardest kanji wins
+		{"鬱", 0, "unlisted"},
+		{"難鬱", 0, "unlisted"}, // One kanji was never on the test
+		{"すごい", 0, "unlisted"},
+	}
+	for _, test := range tests {
+		if got := wordJLPT(test.word); got != test.level {
+			t.Errorf("wordJLPT(%s) = %d, want %d", test.word, got, test.level)
+		}
+		if got := wordJLPTLabel(test.word); got != test.label {
+			t.Errorf("wordJLPTLabel(%s) = %s, want %s", test.word, got, test.label)
+		}
+	}
+
+	kanji := map[string]string{"難": "N3", "食": "Old 4", "鬱": "unlisted", "あ": "unlisted"}
+	for k, want := range kanji {
+		if got := kanjiJLPTLabel(k); got != want {
+			t.Errorf("kanjiJLPTLabel(%s) = %s, want %s", k, got, want)
+		}
+	}
+}
+
+func TestFrequencyBand(t *testing.T) {
+	tests := map[int]string{
+		0:     "unranked",
+		1:     "1-1000",
+		1000:  "1-1000",
+		1001:  "1001-5000",
+		20000: "10001-20000",
+		20001: "20001+",
+	}
+	for rank, want := range tests {
+		if got := frequencyBand(rank); got != want {
+			t.Errorf("frequencyBand(%d) = %s, want %s", rank, got, want)
+		}
+	}
+}
+
+func TestContentReportFinish(t *testing.T) {
+	useLevels(t)
+	useKnown(t, []string{"食べる"}, []string{"食"})
+
+	report := newContentReport("Show")
+	report.addSentence("食べる。難しい", []any{
+		Word{Pos: "verb", DictForm: "食べる", Word: "食べる"},
+		Word{Pos: "symbol", DictForm: "。", Word: "。"},
+		Word{Pos: "adjective", DictForm: "難しい", Word: "難しい"},
+		Word{Pos: "opaque", DictForm: "ABC", Word: "ABC"},
+		Verb{Word: Word{Pos: "verb", DictForm: "食べる", Word: "食べ"}},
+	})
+	report.addSentence("飲む", []any{Verb{Word: Word{Pos: "verb", DictForm: "飲む", Word: "飲む"}}})
+	report.finish()
+
+	want := ContentReport{
+		Source:        "Show",
+		Sentences:     2,
+		Tokens:        4,
+		KnownTokens:   2,
+		KnownPercent:  50,
+		UniqueWords:   3,
+		UnknownWords:  2,
+		WordJLPT:      map[string]int{"N5": 1, "N3": 1, "Old 4": 1},
+		WordFrequency: map[string]int{"1-1000": 1, "5001-10000": 1, "unranked": 1},
+		UniqueKanji:   3,
+		KnownKanji:    1,
+		KanjiCoverage: 100.0 / 3,
+		KanjiJLPT:     map[string]int{"Old 4": 2, "N3": 1},
+		AverageChars:  4.5,
+		AverageTokens: 2,
+	}
+	report.words, report.kanji, report.chars = nil, nil, 0
+	if !reflect.DeepEqual(*report, want) {
+		t.Errorf("finish = %+v, want %+v", *report, want)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// How much of a sentence goes into its note's file name, the hash keeps long sentences apart
+const sentenceNameLength = 40
+
+// Characters file systems or markdown links can't take in a file name
+const unsafeNameChars = `\/:*?"<>|#^[]`
+
+// Sentence cards, mined cards and word cards' examples are written in parallel and can land on the same note
+var sentenceNotesMu sync.Mutex
+
+// SentenceFileName names a sentence's note: the start of the sentence with unsafe characters swapped out, and a short hash of the whole thing
+// 猫が好きです。 => 猫が好きです。_1f3c8a9e
+func sentenceFileName(sentence string) string {
+	var name []rune
+	for _, r := range sentence {
+		if len(name) == sentenceNameLength {
+			break
+		}
+		if strings.ContainsRune(unsafeNameChars, r) || unicode.IsControl(r) || unicode.IsSpace(r) {
+			r = '_'
+		}
+		name = append(name, r)
+	}
+	sum := sha1.Sum([]byte(sentence))
+	return strings.Trim(string(name), "._") + "_" + hex.EncodeToString(sum[:4])
+}
+
+// SentenceNotePath is where a sentence's note is written
+func sentenceNotePath(sentence string) string {
+	return filepath.Join(sentencesPath, sentenceFileName(sentence)+".md")
+}
+
+// SentenceLink links a sentence's note, showing the whole sentence
+func sentenceLink(sentence string) string {
+	return fmt.Sprintf("[%s](%s\\%s.md)", sentence, sentencesPath, sentenceFileName(sentence))
+}
+
+// WriteSentenceCard writes a sentence's note, a sentence that already has one from another source keeps it and gets this source's tags and scene added
+// An external example's card is replaced, the sentence is in the vault's content now
+func writeSentenceCard(content []string, sentence Sentence) {
+	sentenceNotesMu.Lock()
+	defer sentenceNotesMu.Unlock()
+
+	path := sentenceNotePath(sentence.Text)
+	if _, err := os.Stat(path); err != nil {
+		writeCard(strings.Join(content, "\n"), path)
+		return
+	}
+
+	addContentTags(path)
+	lines, err := readLines(path)
+	if err != nil {
+		return
+	}
+	start, end := slices.Index(content, "START"), slices.Index(content, "END")
+	switch _, _, external := externalCard(lines); {
+	case external:
+		lines = replaceExternalCard(lines, content[start:end+1])
+	// Notes made by mine only have a cloze card
+	case !containsRune(lines, "Basic"):
+		lines = insertCardBlock(lines, content[start:end+1])
+	}
+	for _, line := range sentenceMetadata(sentence) {
+		if !containsRune(lines, line) {
+			lines = append(lines, line)
+		}
+	}
+	err = os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
+	if err != nil {
+		fmt.Printf("Error writing to %s: %v\n", path, err)
+	}
+}
+
+// MigrateSentencesCommand renames sentence notes made before notes were named by sentenceFileName and fixes every link to them
+func migrateSentencesCommand() {
+	renames := map[string]string{}
+	filepath.WalkDir(sentencesPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+		rel, err := filepath.Rel(sentencesPath, path)
+		if err != nil {
+			return nil
+		}
+		// Sentences with a / in them ended up in subfolders
+		oldName := strings.TrimSuffix(filepath.ToSlash(rel), ".md")
+
+		lines, err := readLines(path)
+		if err != nil {
+			return nil
+		}
+		sentence := oldName
+		for _, line := range lines {
+			if strings.HasPrefix(line, "Sentence: ") {
+				sentence = strings.TrimPrefix(line, "Sentence: ")
+			}
+		}
+		newName := sentenceFileName(sentence)
+		if newName == oldName {
+			return nil
+		}
+
+		newPath := filepath.Join(sentencesPath, newName+".md")
+		if _, err := os.Stat(newPath); err == nil {
+			fmt.Printf("Skipping %s, %s already exists\n", path, newPath)
+			return nil
+		}
+		if !containsRune(lines, "Sentence: "+sentence) {
+			lines = append(lines, "Sentence: "+sentence)
+		}
+		if err := os.WriteFile(newPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			fmt.Printf("Error writing to %s: %v\n", newPath, err)
+			return nil
+		}
+		os.Remove(path)
+		renames[oldName] = newName
+		return nil
+	})
+	removeEmptyDirs(sentencesPath)
+
+	if len(renames) == 0 {
+		fmt.Println("Sentence notes are already named the new way")
+		return
+	}
+
+	// Content notes, the Sentences index and Unknown.md all link sentence notes
+	linking := []string{sentencesMd, unknownMd}
+	filepath.WalkDir(contentPath, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && filepath.Ext(path) == ".md" {
+			linking = append(linking, path)
+		}
+		return nil
+	})
+	for _, path := range linking {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		text := string(data)
+		for oldName, newName := range renames {
+			text = strings.ReplaceAll(text,
+				fmt.Sprintf("%s\\%s.md)", sentencesPath, oldName),
+				fmt.Sprintf("%s\\%s.md)", sentencesPath, newName))
+		}
+		if text == string(data) {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			fmt.Printf("Error writing to %s: %v\n", path, err)
+		}
+	}
+	fmt.Printf("Renamed %d sentence notes\n", len(renames))
+}
+
+// RemoveEmptyDirs clears out the folders old sentence names with a / in them left behind
+func removeEmptyDirs(root string) {
+	var dirs []string
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() && path != root {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	// Deepest first so a parent is empty by the time we get to it
+	for i := len(dirs) - 1; i >= 0; i-- {
+		os.Remove(dirs[i])
+	}
+}
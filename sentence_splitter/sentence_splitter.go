@@ -0,0 +1,184 @@
+package sentence_splitter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Opening brackets and the closer each one waits for
+var brackets = map[rune]rune{
+	'「': '」',
+	'『': '』',
+	'（': '）',
+	'(': ')',
+	'【': '】',
+	'〈': '〉',
+	'《': '》',
+	'［': '］',
+	'[': ']',
+	'〔': '〕',
+	'“': '”',
+	'‘': '’',
+}
+
+// Marks that end a sentence, a run of them ends it once: ！？！？ and ... stay together
+const terminals = "。．.！？!?‼⁇⁈⁉"
+
+// What a quote is followed by when the sentence carries on: 「行くよ。」と言った, 「行くよ。」って
+const quotatives = "とっ"
+
+// Ellipses only end a sentence when a space or the end of the line comes after them, or another mark ends the run
+const ellipses = "…‥"
+
+// Sentences splits text at terminal punctuation, keeping the punctuation on the sentence it ends
+// Nothing inside 「」, 『』 and the other brackets is split, so dialogue stays whole, nested or not
+// Newlines always end a sentence
+func Sentences(text string) []string {
+	var output []string
+	var current []rune
+	var stack []rune
+	runes := []rune(text)
+
+	flush := func() {
+		output = appendSentence(output, string(current))
+		current = nil
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\n' {
+			flush()
+			stack = nil
+			continue
+		}
+		current = append(current, r)
+
+		if closer, opens := brackets[r]; opens {
+			stack = append(stack, closer)
+			continue
+		}
+		if len(stack) > 0 {
+			if r == stack[len(stack)-1] {
+				stack = stack[:len(stack)-1]
+				// 「行くよ。」「うん。」 is two sentences, 「行くよ。」と言った is one
+				if len(stack) == 0 && endsQuote(runes, i) {
+					flush()
+				}
+			}
+			continue
+		}
+
+		if !isTerminal(r) && !isEllipsis(r) {
+			continue
+		}
+		// Take the whole run of marks along with any closers stray after them
+		end := i + 1
+		for end < len(runes) && (isTerminal(runes[end]) || isEllipsis(runes[end])) {
+			end++
+		}
+		marks := runes[i:end]
+		for end < len(runes) && isCloser(runes[end]) {
+			end++
+		}
+		current = append(current, runes[i+1:end]...)
+		start := i
+		i = end - 1
+
+		if isEllipsisRun(marks) && end < len(runes) && !unicode.IsSpace(runes[end]) {
+			// …, ‥ and ... mid-sentence are a pause
+			continue
+		}
+		if len(marks) == 1 && marks[0] == '.' && isOpaqueNeighbour(runes, start) {
+			// 3.14, Mr.Smith
+			continue
+		}
+		flush()
+	}
+	flush()
+	return output
+}
+
+// Lines makes every line its own sentence, however it's punctuated
+func Lines(text string) []string {
+	var output []string
+	for _, line := range strings.Split(text, "\n") {
+		output = appendSentence(output, line)
+	}
+	return output
+}
+
+// AppendSentence adds a trimmed sentence, a piece that's nothing but punctuation goes onto the sentence before it
+func appendSentence(output []string, sentence string) []string {
+	sentence = strings.TrimSpace(sentence)
+	if sentence == "" {
+		return output
+	}
+	for _, r := range sentence {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return append(output, sentence)
+		}
+	}
+	if len(output) > 0 {
+		output[len(output)-1] += sentence
+	}
+	return output
+}
+
+// EndsQuote reports whether the closing bracket at i ends a sentence: another quote, a space or the end of the line follows it,
+// or what was quoted ended in a terminal mark and isn't being quoted with と or って
+func endsQuote(runes []rune, i int) bool {
+	if i+1 >= len(runes) {
+		return true
+	}
+	next := runes[i+1]
+	if _, opens := brackets[next]; opens || unicode.IsSpace(next) {
+		return true
+	}
+	if i == 0 || !(isTerminal(runes[i-1]) || isEllipsis(runes[i-1])) {
+		return false
+	}
+	return !strings.ContainsRune(quotatives, next)
+}
+
+func isTerminal(r rune) bool {
+	return strings.ContainsRune(terminals, r)
+}
+
+func isEllipsis(r rune) bool {
+	return strings.ContainsRune(ellipses, r)
+}
+
+// IsEllipsisRun reports whether a run of marks is an ellipsis, … and ‥ or dots the way NFKC writes them
+func isEllipsisRun(marks []rune) bool {
+	dots := 0
+	for _, r := range marks {
+		switch {
+		case isEllipsis(r):
+		case r == '.' || r == '．':
+			dots++
+		default:
+			return false
+		}
+	}
+	return dots != 1
+}
+
+func isCloser(r rune) bool {
+	for _, closer := range brackets {
+		if r == closer {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpaqueNeighbour reports whether a full stop at i sits between Latin letters or digits
+func isOpaqueNeighbour(runes []rune, i int) bool {
+	if i == 0 || i+1 >= len(runes) {
+		return false
+	}
+	isAlnum := func(r rune) bool {
+		return r < 0x80 && (unicode.IsLetter(r) || unicode.IsDigit(r))
+	}
+	return isAlnum(runes[i-1]) && isAlnum(runes[i+1])
+}
@@ -0,0 +1,41 @@
+package sentence_splitter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSentences(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"今日は晴れ。明日は雨！", []string{"今日は晴れ。", "明日は雨！"}},
+		{"本当？！嘘でしょ", []string{"本当？！", "嘘でしょ"}},
+		{"「行くよ。」と言った。", []string{"「行くよ。」と言った。"}},
+		{"「行くよ。」って。", []string{"「行くよ。」って。"}},
+		{"「行くよ。」「うん。」", []string{"「行くよ。」", "「うん。」"}},
+		{"『彼は「待て。」と。』それで", []string{"『彼は「待て。」と。』", "それで"}},
+		{"そうか…分かった。", []string{"そうか…分かった。"}},
+		{"そうか… 分かった。", []string{"そうか…", "分かった。"}},
+		{"待って...行かないで", []string{"待って...行かないで"}},
+		{"円周率は3.14です。", []string{"円周率は3.14です。"}},
+		{"終わり。）次", []string{"終わり。）", "次"}},
+		{"一行目\n二行目", []string{"一行目", "二行目"}},
+		{"はい。！", []string{"はい。！"}},
+		{"", nil},
+	}
+	for _, test := range tests {
+		if got := Sentences(test.text); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Sentences(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestLines(t *testing.T) {
+	got := Lines("一行目。まだ一行目\n\n  二行目  \n！")
+	want := []string{"一行目。まだ一行目", "二行目！"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,231 @@
+package settings_handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type Settings struct {
+	FuriganaMode  string `json:"furiganaMode"`
+	JMnedictPath  string `json:"jmnedictPath"`
+	JLPTPath      string `json:"jlptPath"`
+	KanjidicPath  string `json:"kanjidicPath"`
+	FrequencyPath string `json:"frequencyPath"`
+	TokenizerDic  string `json:"tokenizerDic"`
+	TokenizerMode string `json:"tokenizerMode"`
+	LyricsMode    string `json:"lyricsMode"`
+
+	// Normalization of source lines before they're split into sentences
+	NFKC            bool `json:"nfkc"`
+	KeepDigits      bool `json:"keepDigits"`
+	KeepLatin       bool `json:"keepLatin"`
+	DropNonJapanese bool `json:"dropNonJapanese"`
+
+	// How each kind of source is cut into sentences
+	ProseSplit    string `json:"proseSplit"`
+	SubtitleSplit string `json:"subtitleSplit"`
+	LyricsSplit   string `json:"lyricsSplit"`
+	MangaSplit    string `json:"mangaSplit"`
+
+	KnownMaturity string `json:"knownMaturity"`
+	MiningMode    string `json:"miningMode"`
+
+	// Where sentence translations come from
+	Translator      string `json:"translator"`
+	TranslateURL    string `json:"translateUrl"`
+	TranslateAPIKey string `json:"translateApiKey"`
+	TranslateTarget string `json:"translateTarget"`
+	ParallelPath    string `json:"parallelPath"`
+
+	// Example sentences on word cards, from a full JMdict and a Tatoeba export
+	ExamplesPerWord int    `json:"examplesPerWord"`
+	JMdictPath      string `json:"jmdictPath"`
+	TatoebaPath     string `json:"tatoebaPath"`
+}
+
+// Furigana render modes
+const (
+	FuriganaNone     = "none"
+	FuriganaAnki     = "anki"
+	FuriganaHTML     = "html"
+	FuriganaMarkdown = "markdown"
+)
+
+// Tokenizer dictionaries
+const (
+	DicIPA = "ipa"
+	DicUni = "unidic"
+)
+
+// Tokenizer modes
+const (
+	ModeNormal   = "normal"
+	ModeSearch   = "search"
+	ModeExtended = "extended"
+)
+
+// Which sources are read as song lyrics, a line at a time
+const (
+	LyricsLRC  = "lrc"
+	LyricsText = "text"
+	LyricsOff  = "off"
+)
+
+// Sentence splitting
+const (
+	SplitSentence = "sentence" // at terminal punctuation, brackets and quotes are never split
+	SplitLine     = "line"     // every line is a sentence
+	SplitWhole    = "whole"    // a whole cue, lyric or text block is one sentence
+)
+
+// Anki card maturity, knownMaturity is how far along an imported card has to be before its word counts as known
+const (
+	MaturityNew      = "new"
+	MaturityLearning = "learning"
+	MaturityYoung    = "young"
+	MaturityMature   = "mature"
+)
+
+// Sentence mining, with i+1 only sentences with a single unknown word or kanji get a card, a cloze on that item
+const (
+	MiningOff      = "off"
+	MiningIPlusOne = "i+1"
+)
+
+// Translators, libretranslate posts to translateUrl, parallel looks sentences up in the TSV at parallelPath and manual asks in the terminal
+const (
+	TranslatorNone     = "none"
+	TranslatorLibre    = "libretranslate"
+	TranslatorParallel = "parallel"
+	TranslatorManual   = "manual"
+)
+
+func DefaultSettings() Settings {
+	return Settings{
+		FuriganaMode:  FuriganaAnki,
+		JMnedictPath:  "",
+		JLPTPath:      "",
+		KanjidicPath:  "",
+		FrequencyPath: "",
+		TokenizerDic:  DicIPA,
+		TokenizerMode: ModeNormal,
+		LyricsMode:    LyricsLRC,
+
+		NFKC:            true,
+		KeepDigits:      true,
+		KeepLatin:       true,
+		DropNonJapanese: false,
+
+		ProseSplit:    SplitSentence,
+		SubtitleSplit: SplitSentence,
+		LyricsSplit:   SplitLine,
+		MangaSplit:    SplitWhole,
+
+		KnownMaturity: MaturityYoung,
+		MiningMode:    MiningOff,
+
+		Translator:      TranslatorNone,
+		TranslateURL:    "http://localhost:5000/translate",
+		TranslateAPIKey: "",
+		TranslateTarget: "en",
+		ParallelPath:    "",
+
+		ExamplesPerWord: 0,
+		JMdictPath:      "",
+		TatoebaPath:     "",
+	}
+}
+
+func LoadSettings(filePath string) Settings {
+	// Load default values
+	defaults := DefaultSettings()
+	settings := defaults
+
+	// Try opening the JSON file
+	file, err := os.Open(filePath)
+	if err != nil {
+		fmt.Println("Could not open settings.json, using defaults and creating settings.json:", err)
+
+		file, err := os.Create(filePath)
+		if err != nil {
+			fmt.Println("Could not create settings.json", err)
+			return settings
+		}
+		defer file.Close()
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ") // Pretty-print the JSON
+		if err := encoder.Encode(defaults); err != nil {
+			fmt.Println("Could not create settings.json", err)
+		}
+		return settings
+	}
+	defer file.Close()
+
+	// Decode the JSON file
+	decoder := json.NewDecoder(file)
+	if err := decoder.Decode(&settings); err != nil {
+		fmt.Println("Error decoding JSON, using defaults:", err)
+		return defaults
+	}
+
+	// Validate fields and fallback to defaults for unknown values
+	switch settings.FuriganaMode {
+	case FuriganaNone, FuriganaAnki, FuriganaHTML, FuriganaMarkdown:
+	default:
+		settings.FuriganaMode = defaults.FuriganaMode
+	}
+	switch settings.TokenizerDic {
+	case DicIPA, DicUni:
+	default:
+		settings.TokenizerDic = defaults.TokenizerDic
+	}
+	switch settings.TokenizerMode {
+	case ModeNormal, ModeSearch, ModeExtended:
+	default:
+		settings.TokenizerMode = defaults.TokenizerMode
+	}
+	settings.ProseSplit = validSplit(settings.ProseSplit, defaults.ProseSplit)
+	settings.SubtitleSplit = validSplit(settings.SubtitleSplit, defaults.SubtitleSplit)
+	settings.LyricsSplit = validSplit(settings.LyricsSplit, defaults.LyricsSplit)
+	settings.MangaSplit = validSplit(settings.MangaSplit, defaults.MangaSplit)
+	switch settings.KnownMaturity {
+	case MaturityNew, MaturityLearning, MaturityYoung, MaturityMature:
+	default:
+		settings.KnownMaturity = defaults.KnownMaturity
+	}
+	switch settings.MiningMode {
+	case MiningOff, MiningIPlusOne:
+	default:
+		settings.MiningMode = defaults.MiningMode
+	}
+	switch settings.Translator {
+	case TranslatorNone, TranslatorLibre, TranslatorParallel, TranslatorManual:
+	default:
+		settings.Translator = defaults.Translator
+	}
+	if settings.TranslateURL == "" {
+		settings.TranslateURL = defaults.TranslateURL
+	}
+	if settings.TranslateTarget == "" {
+		settings.TranslateTarget = defaults.TranslateTarget
+	}
+	if settings.ExamplesPerWord < 0 {
+		settings.ExamplesPerWord = defaults.ExamplesPerWord
+	}
+	switch settings.LyricsMode {
+	case LyricsLRC, LyricsText, LyricsOff:
+	default:
+		settings.LyricsMode = defaults.LyricsMode
+	}
+	return settings
+}
+
+func validSplit(split, fallback string) string {
+	switch split {
+	case SplitSentence, SplitLine, SplitWhole:
+		return split
+	}
+	return fallback
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/content_reader"
+)
+
+// What each source's sidecar file says, by source name
+var sourceMeta = map[string]content_reader.Meta{}
+
+// FindSources walks New Content and its subfolders for anything there's a reader for
+// Folders of manga OCR pages are one source each and aren't walked into
+func findSources(root string) []string {
+	var output []string
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			fmt.Printf("Error reading %s: %v\n", path, err)
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && content_reader.IsMokuroDir(path) {
+				output = append(output, path)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if content_reader.IsMeta(path) {
+			return nil
+		}
+		if slices.Contains(content_reader.Extensions, strings.ToLower(filepath.Ext(path))) || content_reader.IsMokuroPage(path) {
+			output = append(output, path)
+		}
+		return nil
+	})
+	return output
+}
+
+// SourceName names a source after its path under New Content: Series/Episode 01.srt => Series_Episode_01
+func sourceName(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = filepath.Base(path)
+	}
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		rel = strings.TrimSuffix(rel, filepath.Ext(rel))
+	}
+	return replaceSpaces(strings.Join(strings.Split(filepath.ToSlash(rel), "/"), " "))
+}
+
+// LoadSourceMeta reads a source's sidecar file, a source in a subfolder belongs to that folder's series unless the sidecar says otherwise
+func loadSourceMeta(root, path string) content_reader.Meta {
+	meta, err := content_reader.ReadMeta(path)
+	if err != nil {
+		fmt.Printf("Error reading %s: %v\n", content_reader.MetaPath(path), err)
+	}
+	if meta.Series == "" {
+		if dir := filepath.Dir(path); dir != filepath.Clean(root) {
+			meta.Series = filepath.Base(dir)
+		}
+	}
+	return meta
+}
+
+// MetaHeader is the top of a Content note, everything the sidecar file said about the source and the day it was first processed
+func metaHeader(meta content_reader.Meta, processed string) string {
+	var lines []string
+	add := func(label, value string) {
+		if value != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", label, value))
+		}
+	}
+	add("Title", meta.Title)
+	add("Author", meta.Author)
+	if meta.Series != "" {
+		series := replaceSpaces(meta.Series)
+		add("Series", fmt.Sprintf("[%s](%s\\%s.md)", meta.Series, seriesPath, series))
+	}
+	add("Episode", meta.Episode)
+	add("URL", meta.URL)
+	add("Level", meta.Level)
+	add("Tags", strings.Join(metaTags(meta), " "))
+	add("Processed", processed)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
+
+// ProcessedDate is the day a Content note says its source was first processed, empty for notes written before it was recorded
+func processedDate(lines []string) string {
+	for _, line := range lines {
+		if date, found := strings.CutPrefix(strings.TrimSpace(line), "Processed: "); found {
+			return date
+		}
+	}
+	return ""
+}
+
+// MetaTags are a source's tags the way Anki wants them, without spaces
+func metaTags(meta content_reader.Meta) []string {
+	var output []string
+	for _, tag := range meta.Tags {
+		if tag = replaceSpaces(strings.TrimSpace(tag)); tag != "" {
+			output = append(output, tag)
+		}
+	}
+	return output
+}
+
+// ContentTags is what goes after Tags: on every card made from the current source
+func contentTags() string {
+	tags := []string{fmt.Sprintf("[%s](%s\\%s.md)", currentName, contentPath, currentName)}
+	tags = append(tags, metaTags(sourceMeta[currentName])...)
+	return strings.Join(tags, " ")
+}
+
+// AddContentTags adds the current source's link and tags to the Tags line of every card in a note, skipping any it already has
+func addContentTags(path string) {
+	lines, err := readLines(path)
+	if err != nil {
+		return
+	}
+	for i, line := range lines {
+		if !strings.Contains(line, "Tags: ") {
+			continue
+		}
+		line = strings.TrimSpace(line)
+		existing := strings.Fields(line)
+		for _, tag := range strings.Fields(contentTags()) {
+			if !slices.Contains(existing, tag) {
+				line += " " + tag
+			}
+		}
+		lines[i] = line
+	}
+
+	err = os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
+	if err != nil {
+		fmt.Printf("Error writing to %s: %v\n", path, err)
+	}
+}
+
+// AddToSeries links the current source from its series note, making the note the first time the series comes up
+func addToSeries() {
+	meta := sourceMeta[currentName]
+	if meta.Series == "" {
+		return
+	}
+	path := filepath.Join(seriesPath, replaceSpaces(meta.Series)+".md")
+	lines := []string{meta.Series, ""}
+	if _, err := os.Stat(path); err == nil {
+		if lines, err = readLines(path); err != nil {
+			return
+		}
+	}
+
+	link := fmt.Sprintf("[%s](%s\\%s.md)", currentName, contentPath, currentName)
+	for _, line := range lines {
+		if strings.HasPrefix(line, link) {
+			return
+		}
+	}
+	if meta.Episode != "" {
+		link += " " + meta.Episode
+	}
+	lines = append(lines, link)
+
+	err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+	if err != nil {
+		fmt.Printf("Error writing to %s: %v\n", path, err)
+	}
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"cmp"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Answers in a review, the same four buttons Anki has
+const (
+	GradeAgain = 1
+	GradeHard  = 2
+	GradeGood  = 3
+	GradeEasy  = 4
+)
+
+const (
+	// Where a card's ease starts and the lowest SM-2 lets it go
+	startingEase = 2.5
+	minimumEase  = 1.3
+	// New cards shown in one review session unless the command says otherwise
+	newCardsPerSession = 20
+	dateLayout         = "2006-01-02"
+)
+
+// SM-2 rates answers from 0 to 5, anything under 3 is a lapse
+var gradeQuality = map[int]int{
+	GradeAgain: 1,
+	GradeHard:  3,
+	GradeGood:  4,
+	GradeEasy:  5,
+}
+
+var clozeRe = regexp.MustCompile(`\{\{c\d+::(.*?)\}\}`)
+
+// ReviewState is a card's SM-2 scheduling
+type ReviewState struct {
+	Ease        float64 `json:"ease"`
+	Interval    int     `json:"interval"` // Days until the card is due again
+	Repetitions int     `json:"repetitions"`
+	Lapses      int     `json:"lapses"`
+	Due         string  `json:"due"`
+	LastReview  string  `json:"lastReview"`
+}
+
+// ReviewStore is the state of every card that has been reviewed, by reviewKey
+type ReviewStore map[string]ReviewState
+
+// ReviewKey names a card by its note, relative to the notes directory so the vault can move, and a hash of its front or cloze
+// Mining and examples add cards to notes that already have some, so a card's place in its note can change but its text doesn't
+func reviewKey(card FlashcardDict) string {
+	note := card.Note
+	if rel, err := filepath.Rel(pathing.NotesDir, card.Note); err == nil {
+		note = rel
+	}
+	sum := sha1.Sum([]byte(card.Front + "\x00" + card.Cloze))
+	return fmt.Sprintf("%s#%x", filepath.ToSlash(note), sum[:8])
+}
+
+// LoadReviews reads the review state sidecar
+func loadReviews() ReviewStore {
+	store := ReviewStore{}
+	data, err := os.ReadFile(reviewJson)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Printf("Error reading %s: %v\n", reviewJson, err)
+		}
+		return store
+	}
+	if err := json.Unmarshal(data, &store); err != nil {
+		fmt.Printf("Error decoding %s: %v\n", reviewJson, err)
+	}
+	return store
+}
+
+// SaveReviews writes the review state sidecar
+func saveReviews(store ReviewStore) {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding %s: %v\n", reviewJson, err)
+		return
+	}
+	if err := os.WriteFile(reviewJson, data, 0644); err != nil {
+		fmt.Printf("Error writing to %s: %v\n", reviewJson, err)
+	}
+}
+
+// Schedule works out a card's next review from an answer with SM-2
+func schedule(state ReviewState, grade int, today time.Time) ReviewState {
+	if state.Ease == 0 {
+		state.Ease = startingEase
+	}
+	quality := gradeQuality[grade]
+
+	if quality < 3 {
+		state.Repetitions = 0
+		state.Interval = 1
+		state.Lapses++
+	} else {
+		switch state.Repetitions {
+		case 0:
+			state.Interval = 1
+		case 1:
+			state.Interval = 6
+		default:
+			state.Interval = int(math.Round(float64(state.Interval) * state.Ease))
+		}
+		state.Repetitions++
+	}
+	q := float64(5 - quality)
+	state.Ease = max(state.Ease+0.1-q*(0.08+q*0.02), minimumEase)
+
+	state.LastReview = today.Format(dateLayout)
+	state.Due = today.AddDate(0, 0, state.Interval).Format(dateLayout)
+	return state
+}
+
+// ReviewQueue is the cards due by today, most overdue first, then up to newLimit cards that have never been reviewed in export order
+func reviewQueue(cards []FlashcardDict, store ReviewStore, today time.Time, newLimit int) []FlashcardDict {
+	var due, fresh []FlashcardDict
+	for _, card := range cards {
+		state, reviewed := store[reviewKey(card)]
+		if !reviewed {
+			if len(fresh) < newLimit {
+				fresh = append(fresh, card)
+			}
+			continue
+		}
+		if state.Due <= today.Format(dateLayout) {
+			due = append(due, card)
+		}
+	}
+	slices.SortStableFunc(due, func(a, b FlashcardDict) int {
+		return cmp.Compare(store[reviewKey(a)].Due, store[reviewKey(b)].Due)
+	})
+	return append(due, fresh...)
+}
+
+// CardSides is what a card shows before and after it's turned over, links are shown as their text
+func cardSides(card FlashcardDict) (string, string) {
+	if card.Cloze != "" {
+		front := clozeRe.ReplaceAllString(card.Cloze, "[...]")
+		back := clozeRe.ReplaceAllString(card.Cloze, "$1")
+		if card.Back != "" {
+			back += "\n" + card.Back
+		}
+		return markdownLinkRe.ReplaceAllString(front, "$1"), markdownLinkRe.ReplaceAllString(back, "$1")
+	}
+	return markdownLinkRe.ReplaceAllString(card.Front, "$1"), markdownLinkRe.ReplaceAllString(card.Back, "$1")
+}
+
+// ReviewCommand runs a review session in the terminal
+//
+//	review [sentences|words|kanji|names] [new cards]
+func reviewCommand(args []string) {
+	usage := "Usage: review [sentences|words|kanji|names] [new cards]"
+	dirs := map[string]string{
+		"sentences": sentencesPath,
+		"words":     wordsPath,
+		"kanji":     kanjiPath,
+		"names":     namesPath,
+	}
+	decks := []string{"sentences", "words", "kanji", "names"}
+	newLimit := newCardsPerSession
+	for _, arg := range args {
+		if _, exists := dirs[arg]; exists {
+			decks = []string{arg}
+			continue
+		}
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			fmt.Println(usage)
+			return
+		}
+		newLimit = n
+	}
+
+	var cards []FlashcardDict
+	for _, deck := range decks {
+		files, _ := filepath.Glob(filepath.Join(dirs[deck], "*.md"))
+		cards = append(cards, filesToFlashcardClass(files)...)
+	}
+	store := loadReviews()
+	today := time.Now()
+	queue := reviewQueue(cards, store, today, newLimit)
+	if len(queue) == 0 {
+		fmt.Println("Nothing to review today")
+		return
+	}
+
+	reviewed := 0
+	missed := map[string]bool{}
+	for len(queue) > 0 {
+		card := queue[0]
+		queue = queue[1:]
+		front, back := cardSides(card)
+
+		fmt.Printf("\n[%d left] %s\n", len(queue)+1, front)
+		fmt.Println("(Enter to show the answer, q to stop)")
+		if !stdin.Scan() || strings.TrimSpace(strings.ToLower(stdin.Text())) == "q" {
+			break
+		}
+		fmt.Println(back)
+
+		grade := 0
+		for grade == 0 {
+			fmt.Println("1 again, 2 hard, 3 good, 4 easy")
+			if !stdin.Scan() {
+				saveReviews(store)
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(stdin.Text()))
+			if err == nil && n >= GradeAgain && n <= GradeEasy {
+				grade = n
+			}
+		}
+
+		// A card you missed comes back at the end of the session until you get it, it's only scheduled the first time
+		key := reviewKey(card)
+		if !missed[key] {
+			store[key] = schedule(store[key], grade, today)
+			reviewed++
+		}
+		if grade == GradeAgain {
+			missed[key] = true
+			queue = append(queue, card)
+		}
+		saveReviews(store)
+	}
+	fmt.Printf("Reviewed %d cards\n", reviewed)
+}
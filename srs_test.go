@@ -0,0 +1,134 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+var reviewDay = time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+func TestSchedule(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  ReviewState
+		grade  int
+		want   ReviewState
+		wantEF float64
+	}{
+		{
+			"first review",
+			ReviewState{},
+			GradeGood,
+			ReviewState{Interval: 1, Repetitions: 1, Due: "2026-01-11"},
+			2.5,
+		},
+		{
+			"second review",
+			ReviewState{Ease: 2.5, Interval: 1, Repetitions: 1},
+			GradeGood,
+			ReviewState{Interval: 6, Repetitions: 2, Due: "2026-01-16"},
+			2.5,
+		},
+		{
+			"third review is the last interval times the ease",
+			ReviewState{Ease: 2.5, Interval: 6, Repetitions: 2},
+			GradeGood,
+			ReviewState{Interval: 15, Repetitions: 3, Due: "2026-01-25"},
+			2.5,
+		},
+		{
+			"intervals are rounded",
+			ReviewState{Ease: 1.7, Interval: 7, Repetitions: 4},
+			GradeEasy,
+			ReviewState{Interval: 12, Repetitions: 5, Due: "2026-01-22"},
+			1.8,
+		},
+		{
+			"hard lowers the ease",
+			ReviewState{Ease: 2.5, Interval: 6, Repetitions: 2},
+			GradeHard,
+			ReviewState{Interval: 15, Repetitions: 3, Due: "2026-01-25"},
+			2.36,
+		},
+		{
+			"a lapse starts the card over",
+			ReviewState{Ease: 2.5, Interval: 40, Repetitions: 6, Lapses: 1},
+			GradeAgain,
+			ReviewState{Interval: 1, Repetitions: 0, Lapses: 2, Due: "2026-01-11"},
+			1.96,
+		},
+		{
+			"the ease doesn't go under 1.3",
+			ReviewState{Ease: 1.4, Interval: 10, Repetitions: 3},
+			GradeAgain,
+			ReviewState{Interval: 1, Repetitions: 0, Lapses: 1, Due: "2026-01-11"},
+			1.3,
+		},
+	}
+	for _, test := range tests {
+		got := schedule(test.state, test.grade, reviewDay)
+		if got.Interval != test.want.Interval || got.Repetitions != test.want.Repetitions || got.Lapses != test.want.Lapses || got.Due != test.want.Due {
+			t.Errorf("%s: schedule = %+v, want %+v", test.name, got, test.want)
+		}
+		if diff := got.Ease - test.wantEF; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%s: ease = %v, want %v", test.name, got.Ease, test.wantEF)
+		}
+		if got.LastReview != "2026-01-10" {
+			t.Errorf("%s: last review = %s", test.name, got.LastReview)
+		}
+	}
+}
+
+func TestReviewQueue(t *testing.T) {
+	card := func(front string) FlashcardDict {
+		return FlashcardDict{Front: front, Note: "Words/" + front + ".md"}
+	}
+	late, later, today, tomorrow := card("late"), card("later"), card("today"), card("tomorrow")
+	store := ReviewStore{
+		reviewKey(later):    {Due: "2026-01-05"},
+		reviewKey(late):     {Due: "2026-01-08"},
+		reviewKey(today):    {Due: "2026-01-10"},
+		reviewKey(tomorrow): {Due: "2026-01-11"},
+	}
+	cards := []FlashcardDict{card("new1"), late, tomorrow, card("new2"), today, later, card("new3")}
+
+	tests := []struct {
+		name     string
+		newLimit int
+		want     []string
+	}{
+		{"most overdue first, then new cards in order", 2, []string{"later", "late", "today", "new1", "new2"}},
+		{"no new cards", 0, []string{"later", "late", "today"}},
+		{"the limit is only a limit", 10, []string{"later", "late", "today", "new1", "new2", "new3"}},
+	}
+	for _, test := range tests {
+		queue := reviewQueue(cards, store, reviewDay, test.newLimit)
+		var got []string
+		for _, c := range queue {
+			got = append(got, c.Front)
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("%s: queue = %v, want %v", test.name, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: queue = %v, want %v", test.name, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestReviewKeyFollowsCardText(t *testing.T) {
+	basic := FlashcardDict{Front: "食べる", Note: "Sentences/a.md"}
+	cloze := FlashcardDict{Cloze: "{{c1::食べる}}", Note: "Sentences/a.md"}
+	if reviewKey(basic) == reviewKey(cloze) {
+		t.Error("two cards in one note share a key")
+	}
+	moved := basic
+	moved.Priority = 3
+	if reviewKey(basic) != reviewKey(moved) {
+		t.Error("the key changed without the card's text changing")
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// How many items each top list in Stats.md shows
+const statsTop = 50
+
+// Links as the indexes and Tags lines write them, [text](dir\name.md)
+var noteLinkRe = regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\.md\)`)
+
+// StatCount is a word, kanji or source and how many times it counts
+type StatCount struct {
+	Item  string `json:"item"`
+	Count int    `json:"count"`
+}
+
+// SourceStats is what a source brought into the vault, words and kanji belong to the source whose tag they got first
+type SourceStats struct {
+	Source    string `json:"source"`
+	Date      string `json:"date"`
+	Sentences int    `json:"sentences"`
+	NewWords  int    `json:"newWords"`
+	NewKanji  int    `json:"newKanji"`
+}
+
+// GrowthPoint is the size of the vault at the end of a day sources were processed
+type GrowthPoint struct {
+	Date    string `json:"date"`
+	Sources int    `json:"sources"`
+	Words   int    `json:"words"`
+	Kanji   int    `json:"kanji"`
+}
+
+// VaultStats is everything the stats command reports
+type VaultStats struct {
+	Generated   string        `json:"generated"`
+	Sources     int           `json:"sources"`
+	Words       int           `json:"words"`
+	Kanji       int           `json:"kanji"`
+	Sentences   int           `json:"sentences"`
+	TopWords    []StatCount   `json:"topWords"`    // Times a word is used across every source's sentences
+	TopKanji    []StatCount   `json:"topKanji"`    // Times a kanji is written across every source's sentences
+	WidestWords []StatCount   `json:"widestWords"` // How many sources' sentences each word is used in
+	BySource    []SourceStats `json:"bySource"`
+	Growth      []GrowthPoint `json:"growth"`
+	OnlyOnce    []string      `json:"onlyOnce"` // Words used a single time in the whole vault
+}
+
+// IndexLinks lists the notes an index links to in dir, in the order the index has them
+func indexLinks(index, dir string) []string {
+	lines, err := readLines(index)
+	if err != nil {
+		return nil
+	}
+	var output []string
+	for _, line := range lines {
+		for _, match := range noteLinkRe.FindAllStringSubmatch(line, -1) {
+			if name, found := strings.CutPrefix(match[2], dir+"\\"); found && !containsRune(output, name) {
+				output = append(output, name)
+			}
+		}
+	}
+	return output
+}
+
+// NoteSources lists the sources a note's Tags lines link, the first is the source the note was made for
+func noteSources(lines []string) []string {
+	var output []string
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "Tags:") {
+			continue
+		}
+		for _, match := range noteLinkRe.FindAllStringSubmatch(line, -1) {
+			if name, found := strings.CutPrefix(match[2], contentPath+"\\"); found && !containsRune(output, name) {
+				output = append(output, name)
+			}
+		}
+	}
+	return output
+}
+
+// SentenceWords lists the words a sentence note's Basic card links, a mined note with only a cloze card
+// links nothing, so its sentence is parsed and the words that have notes in the vault are kept
+func sentenceWords(front, text string, inVault map[string]bool) []string {
+	var output []string
+	if front != "" {
+		for _, match := range noteLinkRe.FindAllStringSubmatch(front, -1) {
+			if word, found := strings.CutPrefix(match[2], wordsPath+"\\"); found {
+				output = append(output, word)
+			}
+		}
+		return output
+	}
+	for _, parsed := range parser(text) {
+		var word string
+		switch v := parsed.(type) {
+		case Word:
+			word = v.DictForm
+		case Verb:
+			word = v.Word.DictForm
+		}
+		if inVault[word] {
+			output = append(output, word)
+		}
+	}
+	return output
+}
+
+// TopCounts sorts counts highest first, ties in item order, and keeps the first n
+func topCounts(counts map[string]int, n int) []StatCount {
+	output := make([]StatCount, 0, len(counts))
+	for item, count := range counts {
+		output = append(output, StatCount{Item: item, Count: count})
+	}
+	slices.SortFunc(output, func(a, b StatCount) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return cmp.Compare(a.Item, b.Item)
+	})
+	if len(output) > n {
+		output = output[:n]
+	}
+	return output
+}
+
+// CollectStats walks the indexes and the notes they link
+func collectStats() VaultStats {
+	stats := VaultStats{Generated: time.Now().Format("2006-01-02 15:04")}
+	sources := indexLinks(contentMd, contentPath)
+	words := indexLinks(wordsMd, wordsPath)
+	kanji := indexLinks(kanjiMd, kanjiPath)
+	sentences := indexLinks(sentencesMd, sentencesPath)
+	stats.Sources, stats.Words, stats.Kanji, stats.Sentences = len(sources), len(words), len(kanji), len(sentences)
+
+	bySource := map[string]*SourceStats{}
+	for _, source := range sources {
+		lines, _ := readLines(filepath.Join(contentPath, source+".md"))
+		bySource[source] = &SourceStats{Source: source, Date: processedDate(lines)}
+	}
+
+	inVault := map[string]bool{}
+	for _, word := range words {
+		inVault[word] = true
+	}
+
+	// A sentence counts once for every source it comes up in
+	wordUses := map[string]int{}
+	kanjiUses := map[string]int{}
+	// Word notes only get the tag of the source that made them, sentence notes get every source's
+	wordSources := map[string]map[string]bool{}
+	for _, name := range sentences {
+		lines, err := readLines(filepath.Join(sentencesPath, name+".md"))
+		if err != nil {
+			continue
+		}
+		tagged := noteSources(lines)
+		for _, source := range tagged {
+			if stat, exists := bySource[source]; exists {
+				stat.Sentences++
+			}
+		}
+		times := max(len(tagged), 1)
+
+		text := name
+		front := ""
+		for i, line := range lines {
+			if sentence, found := strings.CutPrefix(line, "Sentence: "); found {
+				text = sentence
+			}
+			if strings.TrimSpace(line) == "Basic" && i+1 < len(lines) && front == "" {
+				front = lines[i+1]
+			}
+		}
+		for _, word := range sentenceWords(front, text, inVault) {
+			wordUses[word] += times
+			if wordSources[word] == nil {
+				wordSources[word] = map[string]bool{}
+			}
+			for _, source := range tagged {
+				wordSources[word][source] = true
+			}
+		}
+		for _, c := range text {
+			if isKanjiChar(string(c)) {
+				kanjiUses[string(c)] += times
+			}
+		}
+	}
+
+	for _, word := range words {
+		lines, err := readLines(filepath.Join(wordsPath, word+".md"))
+		if err != nil {
+			continue
+		}
+		if tagged := noteSources(lines); len(tagged) > 0 && bySource[tagged[0]] != nil {
+			bySource[tagged[0]].NewWords++
+		}
+		if wordUses[word] == 1 {
+			stats.OnlyOnce = append(stats.OnlyOnce, word)
+		}
+	}
+	for _, k := range kanji {
+		lines, err := readLines(filepath.Join(kanjiPath, k+".md"))
+		if err != nil {
+			continue
+		}
+		if tagged := noteSources(lines); len(tagged) > 0 && bySource[tagged[0]] != nil {
+			bySource[tagged[0]].NewKanji++
+		}
+	}
+
+	stats.TopWords = topCounts(wordUses, statsTop)
+	stats.TopKanji = topCounts(kanjiUses, statsTop)
+	sourceCounts := map[string]int{}
+	for word, tagged := range wordSources {
+		sourceCounts[word] = len(tagged)
+	}
+	stats.WidestWords = topCounts(sourceCounts, statsTop)
+
+	// Content.md is in the order sources were processed, the day is the Processed line of each Content note
+	for _, source := range sources {
+		stats.BySource = append(stats.BySource, *bySource[source])
+	}
+	ordered := slices.Clone(stats.BySource)
+	slices.SortStableFunc(ordered, func(a, b SourceStats) int { return cmp.Compare(a.Date, b.Date) })
+	var point GrowthPoint
+	for _, source := range ordered {
+		if source.Date != point.Date && point.Date != "" {
+			stats.Growth = append(stats.Growth, point)
+		}
+		point.Date = source.Date
+		point.Sources++
+		point.Words += source.NewWords
+		point.Kanji += source.NewKanji
+	}
+	if point.Sources > 0 {
+		stats.Growth = append(stats.Growth, point)
+	}
+	return stats
+}
+
+// Markdown is the Stats.md dashboard
+func (s VaultStats) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Stats\nGenerated %s\n\n", s.Generated)
+	fmt.Fprintf(&b, "Sources: %d, words: %d, kanji: %d, sentences: %d\n", s.Sources, s.Words, s.Kanji, s.Sentences)
+
+	table := func(title, column string, counts []StatCount, dir string) {
+		fmt.Fprintf(&b, "\n## %s\n| | %s |\n|---|---|\n", title, column)
+		for _, c := range counts {
+			fmt.Fprintf(&b, "| [%s](%s\\%s.md) | %d |\n", c.Item, dir, c.Item, c.Count)
+		}
+	}
+	table("Most Used Words", "Uses", s.TopWords, wordsPath)
+	table("Most Used Kanji", "Uses", s.TopKanji, kanjiPath)
+	table("Words In The Most Sources", "Sources", s.WidestWords, wordsPath)
+
+	b.WriteString("\n## New Vocabulary By Source\n| Source | Processed | Sentences | New words | New kanji |\n|---|---|---|---|---|\n")
+	for _, source := range s.BySource {
+		fmt.Fprintf(&b, "| [%s](%s\\%s.md) | %s | %d | %d | %d |\n", source.Source, contentPath, source.Source, source.Date, source.Sentences, source.NewWords, source.NewKanji)
+	}
+
+	b.WriteString("\n## Growth\n| Date | Sources | Words | Kanji |\n|---|---|---|---|\n")
+	for _, point := range s.Growth {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d |\n", point.Date, point.Sources, point.Words, point.Kanji)
+	}
+
+	fmt.Fprintf(&b, "\n## Words Used Once\n%d words\n", len(s.OnlyOnce))
+	for _, word := range s.OnlyOnce {
+		fmt.Fprintf(&b, "[%s](%s\\%s.md)\n", word, wordsPath, word)
+	}
+	return b.String()
+}
+
+// StatsCommand writes Stats.md and Stats.json for the whole vault
+func statsCommand() {
+	stats := collectStats()
+	err := os.WriteFile(statsMd, []byte(stats.markdown()), 0644)
+	if err != nil {
+		fmt.Printf("Error writing to %s: %v\n", statsMd, err)
+	}
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding %s: %v\n", statsJson, err)
+		return
+	}
+	if err := os.WriteFile(statsJson, data, 0644); err != nil {
+		fmt.Printf("Error writing to %s: %v\n", statsJson, err)
+		return
+	}
+	fmt.Printf("Wrote %s and %s: %d sources, %d words, %d kanji, %d sentences\n", statsMd, statsJson, stats.Sources, stats.Words, stats.Kanji, stats.Sentences)
+}
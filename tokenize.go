@@ -0,0 +1,148 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/settings_handler"
+	"github.com/ikawaha/kagome/tokenizer"
+)
+
+var (
+	sharedTokenizer tokenizer.Tokenizer
+	tokenizeMode    = tokenizer.Normal
+	tokenizerOnce   sync.Once
+)
+
+// UniDic conjugation forms written the way the IPA dictionary writes them, so the verb handling works on either
+var unidicFormMap = map[string]string{
+	"終止形-一般":  "基本形",
+	"連体形-一般":  "基本形",
+	"未然形-一般":  "未然形",
+	"未然形-サ":   "未然形",
+	"未然形-セ":   "未然形",
+	"意志推量形":   "未然ウ接続",
+	"連用形-一般":  "連用形",
+	"連用形-イ音便": "連用タ接続",
+	"連用形-促音便": "連用タ接続",
+	"連用形-撥音便": "連用タ接続",
+	"連用形-ウ音便": "連用タ接続",
+	"連用形-融合":  "連用タ接続",
+	"仮定形-一般":  "仮定形",
+	"命令形":     "命令形",
+}
+
+// BuildTokenizer sets up the one tokenizer every parse goes through, from settings.json
+func buildTokenizer() {
+	switch settings.TokenizerDic {
+	case settings_handler.DicUni:
+		sharedTokenizer = tokenizer.NewWithDic(tokenizer.SysDicUni())
+	default:
+		sharedTokenizer = tokenizer.NewWithDic(tokenizer.SysDicIPA())
+	}
+
+	switch settings.TokenizerMode {
+	case settings_handler.ModeSearch:
+		tokenizeMode = tokenizer.Search
+	case settings_handler.ModeExtended:
+		tokenizeMode = tokenizer.Extended
+	default:
+		tokenizeMode = tokenizer.Normal
+	}
+
+	if userDicLoaded {
+		sharedTokenizer.SetUserDic(userDic)
+	}
+}
+
+// Tokenize splits text with the shared tokenizer, building it the first time it's needed
+func tokenize(text string) []tokenizer.Token {
+	tokenizerOnce.Do(buildTokenizer)
+	return sharedTokenizer.Analyze(text, tokenizeMode)
+}
+
+// TokenFeatures returns a token's features in the IPA layout whichever dictionary produced them:
+// pos, pos detail 1-3, conjugation type, conjugation form, base form, reading, pronunciation
+func tokenFeatures(token tokenizer.Token) []string {
+	features := token.Features()
+	if token.Class == tokenizer.USER || token.Class == tokenizer.DUMMY {
+		return features
+	}
+
+	output := make([]string, 9)
+	for i := range output {
+		output[i] = "*"
+	}
+	if settings.TokenizerDic != settings_handler.DicUni {
+		copy(output, features)
+		if output[6] == "*" {
+			output[6] = token.Surface
+		}
+		return output
+	}
+
+	// UniDic: pos1-4, cType, cForm, lForm, lemma, orth, pron, orthBase, pronBase, ...
+	copy(output, features[:min(len(features), 5)])
+	if len(features) > 5 {
+		output[5] = features[5]
+		if form, exists := unidicFormMap[features[5]]; exists {
+			output[5] = form
+		}
+	}
+	switch {
+	case len(features) > 10 && features[10] != "*":
+		output[6] = features[10]
+	case len(features) > 7:
+		output[6] = features[7]
+	}
+	if len(features) > 9 {
+		// lForm is the dictionary reading, conjugated words need the reading of what's actually there
+		output[7] = features[6]
+		if features[5] != "*" {
+			output[7] = features[9]
+			if !strings.Contains(token.Surface, "ー") {
+				output[7] = expandLongVowels(features[9])
+			}
+		}
+		output[8] = features[9]
+	}
+	if output[6] == "*" {
+		output[6] = token.Surface
+	}
+	return output
+}
+
+// Katakana grouped by vowel, used to spell out the ー in UniDic pronunciations
+var vowelRows = map[rune]string{
+	'ア': "アカガサザタダナハバパマヤャラワァ",
+	'イ': "イキギシジチヂニヒビピミリィ",
+	'ウ': "ウクグスズツヅヌフブプムユュルゥ",
+	'エ': "エケゲセゼテデネヘベペメレェ",
+	'オ': "オコゴソゾトドノホボポモヨョロヲォ",
+}
+
+// Long vowels the way kana spelling writes them, お and え rows are usually lengthened with ウ and イ
+var longVowels = map[rune]rune{
+	'ア': 'ア',
+	'イ': 'イ',
+	'ウ': 'ウ',
+	'エ': 'イ',
+	'オ': 'ウ',
+}
+
+// ExpandLongVowels turns a pronunciation like イコー back into the spelling イコウ
+func expandLongVowels(pron string) string {
+	runes := []rune(pron)
+	for i, r := range runes {
+		if r != 'ー' || i == 0 {
+			continue
+		}
+		for vowel, row := range vowelRows {
+			if strings.ContainsRune(row, runes[i-1]) {
+				runes[i] = longVowels[vowel]
+				break
+			}
+		}
+	}
+	return string(runes)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/settings_handler"
+)
+
+// useDictionary rebuilds the shared tokenizer with another dictionary
+func useDictionary(t *testing.T, dic string) {
+	t.Helper()
+	old := settings.TokenizerDic
+	settings.TokenizerDic = dic
+	tokenizerOnce.Do(func() {})
+	buildTokenizer()
+	t.Cleanup(func() {
+		settings.TokenizerDic = old
+		buildTokenizer()
+	})
+}
+
+// parsedVerbs keeps the verbs parser finds in a sentence
+func parsedVerbs(sentence string) []Verb {
+	var verbs []Verb
+	for _, parsed := range parser(sentence) {
+		if verb, ok := parsed.(Verb); ok {
+			verbs = append(verbs, verb)
+		}
+	}
+	return verbs
+}
+
+func TestGetVerbType(t *testing.T) {
+	tests := map[string]string{
+		"五段・カ行イ音便": "godan",
+		"五段-カ行":    "godan",
+		"一段":       "ichidan",
+		"一段・クレル":   "ichidan",
+		"下一段-バ行":   "ichidan",
+		"上一段-ア行":   "ichidan",
+		"サ変・スル":    "suru",
+		"サ行変格":     "suru",
+		"カ変・来ル":    "kuru",
+		"カ行変格":     "kuru",
+		"*":        "",
+	}
+	for label, want := range tests {
+		if got := getVerbType(label); got != want {
+			t.Errorf("getVerbType(%q) = %q, want %q", label, got, want)
+		}
+	}
+}
+
+func TestVerbAugmentationsMatchAcrossDictionaries(t *testing.T) {
+	sentences := []string{
+		"本を読まない。",
+		"ご飯を食べない。",
+		"明日は来ない。",
+		"雨が降れば行かない。",
+		"手紙を書いている。",
+		"本を読みたい。",
+		"食べてしまった。",
+		"話さなければならない。",
+	}
+	ipa := map[string][]Verb{}
+	useDictionary(t, settings_handler.DicIPA)
+	for _, sentence := range sentences {
+		ipa[sentence] = parsedVerbs(sentence)
+	}
+	useDictionary(t, settings_handler.DicUni)
+	for _, sentence := range sentences {
+		uni := parsedVerbs(sentence)
+		if len(uni) == 0 {
+			t.Errorf("%s: no verbs found", sentence)
+		}
+		if len(uni) != len(ipa[sentence]) {
+			t.Errorf("%s: IPA found %d verbs, UniDic %d", sentence, len(ipa[sentence]), len(uni))
+			continue
+		}
+		for i := range uni {
+			if !reflect.DeepEqual(uni[i], ipa[sentence][i]) {
+				t.Errorf("%s: IPA parsed %+v, UniDic %+v", sentence, ipa[sentence][i], uni[i])
+			}
+		}
+	}
+}
+
+func TestUnknownSymbolsAreNotWords(t *testing.T) {
+	for _, sentence := range []string{"好き♪", "ええ〜", "行く😊", "♪♪♪"} {
+		for _, parsed := range parser(sentence) {
+			if word, ok := parsed.(Word); ok && (word.Unknown || !hasJapanese(word.Word)) {
+				t.Errorf("%s: %q came back as a word", sentence, word.Word)
+			}
+		}
+	}
+
+	verbs := parsedVerbs("行く♪")
+	if len(verbs) != 1 || verbs[0].Word.DictForm != "行く" || verbs[0].Word.Form != "dictionary" {
+		t.Errorf("行く♪: parsed %+v, the verb wasn't ended by ♪", verbs)
+	}
+}
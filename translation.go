@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/settings_handler"
+	"github.com/TheShadowblast123/Japanese-Content-2-Md-And-Anki/translator"
+)
+
+// Where sentence cards get their translations, nil when settings.json doesn't name a translator
+var sentenceTranslator *translator.Cached
+
+// LoadTranslator sets up the translator settings.json asks for, with the cache in Translations.json in front of it
+func loadTranslator() {
+	var t translator.Translator
+	switch settings.Translator {
+	case settings_handler.TranslatorLibre:
+		t = translator.NewLibreTranslate(settings.TranslateURL, settings.TranslateAPIKey, settings.TranslateTarget)
+	case settings_handler.TranslatorParallel:
+		parallel, err := translator.LoadParallel(settings.ParallelPath)
+		if err != nil {
+			fmt.Printf("Error loading %s, sentences won't be translated: %v\n", settings.ParallelPath, err)
+			return
+		}
+		t = parallel
+	case settings_handler.TranslatorManual:
+		t = translator.NewManual(stdin, os.Stdout)
+	default:
+		return
+	}
+
+	cached, err := translator.NewCached(t, translationsJson)
+	if err != nil {
+		fmt.Printf("Error reading %s, starting with no saved translations: %v\n", translationsJson, err)
+	}
+	sentenceTranslator = cached
+}
+
+// TranslateSentence gets a sentence's translation, empty if there's no translator or it failed
+func translateSentence(sentence string) string {
+	if sentenceTranslator == nil {
+		return ""
+	}
+	translation, err := sentenceTranslator.Translate(sentence)
+	if err != nil {
+		fmt.Printf("Error translating %s: %v\n", sentence, err)
+		return ""
+	}
+	return translation
+}
+
+// SaveTranslations writes every translation so far to Translations.json
+func saveTranslations() {
+	if sentenceTranslator == nil {
+		return
+	}
+	if err := sentenceTranslator.Save(); err != nil {
+		fmt.Printf("Error writing to %s: %v\n", translationsJson, err)
+	}
+}
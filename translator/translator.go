@@ -0,0 +1,204 @@
+package translator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Translator turns a Japanese sentence into English, an empty translation with no error means it has none for that sentence
+type Translator interface {
+	Translate(sentence string) (string, error)
+}
+
+// LibreTranslate asks a local LibreTranslate server, or anything with the same /translate endpoint such as an Argos Translate wrapper
+type LibreTranslate struct {
+	URL    string // The whole endpoint, http://localhost:5000/translate
+	APIKey string
+	Source string
+	Target string
+	Client *http.Client
+}
+
+type libreRequest struct {
+	Q      string `json:"q"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Format string `json:"format"`
+	APIKey string `json:"api_key,omitempty"`
+}
+
+type libreResponse struct {
+	TranslatedText string `json:"translatedText"`
+	Error          string `json:"error"`
+}
+
+// NewLibreTranslate sets up a LibreTranslate translator from Japanese into target
+func NewLibreTranslate(url, apiKey, target string) *LibreTranslate {
+	return &LibreTranslate{
+		URL:    url,
+		APIKey: apiKey,
+		Source: "ja",
+		Target: target,
+		Client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (l *LibreTranslate) Translate(sentence string) (string, error) {
+	body, err := json.Marshal(libreRequest{Q: sentence, Source: l.Source, Target: l.Target, Format: "text", APIKey: l.APIKey})
+	if err != nil {
+		return "", err
+	}
+	resp, err := l.Client.Post(l.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result libreResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("%s answered %s: %v", l.URL, resp.Status, err)
+	}
+	if result.Error != "" {
+		return "", fmt.Errorf("%s: %s", l.URL, result.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s answered %s", l.URL, resp.Status)
+	}
+	return strings.TrimSpace(result.TranslatedText), nil
+}
+
+// Parallel looks sentences up in a parallel text the user already has, Japanese<TAB>English on each line
+type Parallel struct {
+	translations map[string]string
+}
+
+// LoadParallel reads a parallel TSV, the first translation of a sentence wins
+func LoadParallel(path string) (*Parallel, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	p := &Parallel{translations: map[string]string{}}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		japanese, english, found := strings.Cut(scanner.Text(), "\t")
+		if !found {
+			continue
+		}
+		japanese = strings.TrimSpace(strings.TrimPrefix(japanese, "\uFEFF"))
+		english, _, _ = strings.Cut(english, "\t")
+		if _, exists := p.translations[japanese]; !exists && japanese != "" {
+			p.translations[japanese] = strings.TrimSpace(english)
+		}
+	}
+	return p, scanner.Err()
+}
+
+func (p *Parallel) Translate(sentence string) (string, error) {
+	return p.translations[strings.TrimSpace(sentence)], nil
+}
+
+// Manual asks for each translation in the terminal, an empty answer leaves the sentence untranslated
+// Questions go one at a time so they don't run into each other when cards are made in parallel
+type Manual struct {
+	In  *bufio.Scanner
+	Out io.Writer
+	mu  sync.Mutex
+}
+
+// NewManual asks through in and out, in should be the scanner the rest of the program reads stdin with
+// so neither one buffers lines meant for the other
+func NewManual(in *bufio.Scanner, out io.Writer) *Manual {
+	return &Manual{In: in, Out: out}
+}
+
+func (m *Manual) Translate(sentence string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fmt.Fprintf(m.Out, "Translate (Enter to skip): %s\n", sentence)
+	if !m.In.Scan() {
+		return "", m.In.Err()
+	}
+	return strings.TrimSpace(m.In.Text()), nil
+}
+
+// Cached keeps every translation in a JSON file so the same sentence is never translated twice
+// Only the cache is locked, the translator behind it is asked in parallel, but only once for a sentence
+// already being translated, anyone else asking for it waits for that answer
+type Cached struct {
+	Translator
+	path     string
+	entries  map[string]string
+	inFlight map[string]*pending
+	mu       sync.Mutex
+}
+
+// pending is a translation that's underway, done is closed once it's in
+type pending struct {
+	done        chan struct{}
+	translation string
+	err         error
+}
+
+// NewCached wraps a translator with the cache at path, a missing file is an empty cache
+func NewCached(t Translator, path string) (*Cached, error) {
+	c := &Cached{Translator: t, path: path, entries: map[string]string{}, inFlight: map[string]*pending{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	return c, json.Unmarshal(data, &c.entries)
+}
+
+func (c *Cached) Translate(sentence string) (string, error) {
+	key := strings.TrimSpace(sentence)
+	c.mu.Lock()
+	if translation, exists := c.entries[key]; exists {
+		c.mu.Unlock()
+		return translation, nil
+	}
+	if p, exists := c.inFlight[key]; exists {
+		c.mu.Unlock()
+		<-p.done
+		return p.translation, p.err
+	}
+	p := &pending{done: make(chan struct{})}
+	c.inFlight[key] = p
+	c.mu.Unlock()
+
+	p.translation, p.err = c.Translator.Translate(key)
+	c.mu.Lock()
+	// Nothing is kept for a sentence that got no translation, so it can be tried again
+	if p.err == nil && p.translation != "" {
+		c.entries[key] = p.translation
+	}
+	delete(c.inFlight, key)
+	c.mu.Unlock()
+	close(p.done)
+	return p.translation, p.err
+}
+
+// Save writes the cache
+func (c *Cached) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0644)
+}
@@ -0,0 +1,233 @@
+package translator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLibreTranslate(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{"translated", http.StatusOK, `{"translatedText": " I eat. "}`, "I eat.", false},
+		{"error body", http.StatusBadRequest, `{"error": "ja is not supported"}`, "", true},
+		{"error body with 200", http.StatusOK, `{"error": "quota"}`, "", true},
+		{"not 200", http.StatusInternalServerError, `{}`, "", true},
+		{"malformed JSON", http.StatusOK, `{"translatedText": `, "", true},
+	}
+	for _, test := range tests {
+		var got libreRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("%s: got a %s with %s", test.name, r.Method, r.Header.Get("Content-Type"))
+			}
+			json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		}))
+
+		translation, err := NewLibreTranslate(server.URL, "key", "en").Translate("食べる。")
+		server.Close()
+		if (err != nil) != test.wantErr || translation != test.want {
+			t.Errorf("%s: Translate = %q, %v", test.name, translation, err)
+		}
+		want := libreRequest{Q: "食べる。", Source: "ja", Target: "en", Format: "text", APIKey: "key"}
+		if got != want {
+			t.Errorf("%s: server got %+v, want %+v", test.name, got, want)
+		}
+	}
+}
+
+func TestLoadParallel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "parallel.tsv")
+	lines := []string{
+		"\uFEFF食べる。\tI eat.",
+		"飲む。\tI drink.\tTatoeba #1",
+		"食べる。\tI will eat.",
+		"no tab on this line",
+		"\tNo Japanese",
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := LoadParallel(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"食べる。":   "I eat.", // BOM dropped, first line wins
+		" 食べる。 ": "I eat.",
+		"飲む。":    "I drink.", // Extra columns ignored
+		"寝る。":    "",
+		"":       "",
+	}
+	for sentence, want := range tests {
+		if got, err := parallel.Translate(sentence); got != want || err != nil {
+			t.Errorf("Translate(%q) = %q, %v, want %q", sentence, got, err, want)
+		}
+	}
+	if _, err := LoadParallel(filepath.Join(t.TempDir(), "missing.tsv")); err == nil {
+		t.Error("a missing file loaded without an error")
+	}
+}
+
+// countingTranslator answers from a map and counts how often it was asked
+type countingTranslator struct {
+	answers map[string]string
+	asked   int
+}
+
+func (c *countingTranslator) Translate(sentence string) (string, error) {
+	c.asked++
+	return c.answers[sentence], nil
+}
+
+func TestCached(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Translations.json")
+	inner := &countingTranslator{answers: map[string]string{"食べる。": "I eat."}}
+	cached, err := NewCached(inner, path)
+	if err != nil {
+		t.Fatalf("a missing cache file is an empty cache: %v", err)
+	}
+
+	// Miss, then hit
+	for range 2 {
+		if got, _ := cached.Translate(" 食べる。"); got != "I eat." {
+			t.Errorf("Translate = %q", got)
+		}
+	}
+	if inner.asked != 1 {
+		t.Errorf("translator asked %d times for one sentence", inner.asked)
+	}
+
+	// An empty translation isn't kept, so it's asked again next time
+	for range 2 {
+		if got, _ := cached.Translate("寝る。"); got != "" {
+			t.Errorf("Translate = %q", got)
+		}
+	}
+	if inner.asked != 3 {
+		t.Errorf("translator asked %d times, an empty translation was cached", inner.asked)
+	}
+
+	if err := cached.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := NewCached(&countingTranslator{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := reloaded.Translate("食べる。"); got != "I eat." {
+		t.Errorf("after a reload Translate = %q", got)
+	}
+	if len(reloaded.entries) != 1 {
+		t.Errorf("reloaded %d entries, want 1", len(reloaded.entries))
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCached(inner, path); err == nil {
+		t.Error("a broken cache file loaded without an error")
+	}
+}
+
+// blockingTranslator waits to be released, so a test can see how many translations are underway at once
+type blockingTranslator struct {
+	inside, release chan struct{}
+}
+
+func (b *blockingTranslator) Translate(sentence string) (string, error) {
+	b.inside <- struct{}{}
+	<-b.release
+	return sentence, nil
+}
+
+func TestCachedTranslatesInParallel(t *testing.T) {
+	inner := &blockingTranslator{inside: make(chan struct{}), release: make(chan struct{})}
+	cached, _ := NewCached(inner, filepath.Join(t.TempDir(), "Translations.json"))
+
+	var wg sync.WaitGroup
+	for _, sentence := range []string{"一。", "二。"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cached.Translate(sentence)
+		}()
+	}
+	for range 2 {
+		select {
+		case <-inner.inside:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the cache let only one translation through at a time")
+		}
+	}
+	close(inner.release)
+	wg.Wait()
+	if len(cached.entries) != 2 {
+		t.Errorf("cached %d translations, want 2", len(cached.entries))
+	}
+}
+
+func TestCachedAsksOnceForASentence(t *testing.T) {
+	inner := &blockingTranslator{inside: make(chan struct{}, 5), release: make(chan struct{})}
+	cached, _ := NewCached(inner, filepath.Join(t.TempDir(), "Translations.json"))
+
+	var wg, started sync.WaitGroup
+	results := make(chan string, 5)
+	for range 5 {
+		wg.Add(1)
+		started.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			translation, _ := cached.Translate("一。")
+			results <- translation
+		}()
+	}
+	<-inner.inside
+	// Give the rest time to get to the cache while the first is still underway
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+	close(results)
+
+	if asked := len(inner.inside); asked != 0 {
+		t.Errorf("the translator was asked %d more times for a sentence already underway", asked)
+	}
+	for translation := range results {
+		if translation != "一。" {
+			t.Errorf("a waiting caller got %q", translation)
+		}
+	}
+}
+
+func TestManual(t *testing.T) {
+	in := bufio.NewScanner(strings.NewReader("I eat.\n\n  I drink.  \n"))
+	var out bytes.Buffer
+	manual := NewManual(in, &out)
+
+	for _, want := range []string{"I eat.", "", "I drink.", ""} {
+		if got, err := manual.Translate("文。"); got != want || err != nil {
+			t.Errorf("Translate = %q, %v, want %q", got, err, want)
+		}
+	}
+	if strings.Count(out.String(), "Translate (Enter to skip): 文。\n") != 4 {
+		t.Errorf("asked %q", out.String())
+	}
+}
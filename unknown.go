@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Ways an unknown token can be resolved
+const (
+	ResolveDictionary = "dictionary"
+	ResolveUserDic    = "userdic"
+	ResolveIgnore     = "ignore"
+)
+
+// UnknownEntry is a token kagome didn't know and what the user decided to do with it
+type UnknownEntry struct {
+	Surface    string   `json:"surface"`
+	Pos        string   `json:"pos"`
+	Sentences  []string `json:"sentences"`
+	Resolution string   `json:"resolution,omitempty"`
+	DictForm   string   `json:"dictForm,omitempty"`
+}
+
+var unknownStore = map[string]UnknownEntry{}
+
+// LoadUnknowns reads the remembered unknown tokens and their resolutions
+func loadUnknowns() {
+	data, err := os.ReadFile(unknownJson)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Printf("Error reading %s: %v\n", unknownJson, err)
+		}
+		return
+	}
+	store := map[string]UnknownEntry{}
+	if err := json.Unmarshal(data, &store); err != nil {
+		fmt.Printf("Error decoding %s: %v\n", unknownJson, err)
+		return
+	}
+	unknownStore = store
+}
+
+// SaveUnknowns writes the unknown store and regenerates the Unknown.md review list
+func saveUnknowns() {
+	data, err := json.MarshalIndent(unknownStore, "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding %s: %v\n", unknownJson, err)
+		return
+	}
+	if err := os.WriteFile(unknownJson, data, 0644); err != nil {
+		fmt.Printf("Error writing to %s: %v\n", unknownJson, err)
+	}
+
+	var surfaces []string
+	for surface, entry := range unknownStore {
+		if entry.Resolution == "" {
+			surfaces = append(surfaces, surface)
+		}
+	}
+	slices.Sort(surfaces)
+
+	content := []string{
+		"Unknown",
+		"Resolve with: resolve <word> dictionary <dictionary form> | resolve <word> userdic <reading> [pos] | resolve <word> ignore",
+		"",
+	}
+	for _, surface := range surfaces {
+		content = append(content, surface)
+		for _, s := range unknownStore[surface].Sentences {
+			content = append(content, "- "+sentenceLink(s))
+		}
+		content = append(content, "")
+	}
+	writeCard(strings.Join(content, "\n"), unknownMd)
+}
+
+// RecordUnknown remembers the sentence an unresolved unknown token came from
+func recordUnknown(word Word, sentence string) {
+	entry, exists := unknownStore[word.Word]
+	if !exists {
+		entry = UnknownEntry{Surface: word.Word, Pos: word.Pos}
+	}
+	if entry.Resolution != "" || slices.Contains(entry.Sentences, sentence) {
+		return
+	}
+	entry.Sentences = append(entry.Sentences, sentence)
+	unknownStore[word.Word] = entry
+}
+
+// ResolveUnknown turns an unknown token into a Word using any remembered resolution, false means drop it
+func resolveUnknown(surface, pos string) (Word, bool) {
+	word := Word{
+		Pos:      pos,
+		DictForm: surface,
+		Form:     "",
+		Word:     surface,
+	}
+	entry, exists := unknownStore[surface]
+	if !exists {
+		word.Unknown = true
+		return word, true
+	}
+	switch entry.Resolution {
+	case ResolveIgnore:
+		return word, false
+	case ResolveDictionary:
+		word.DictForm = entry.DictForm
+	case ResolveUserDic:
+	default:
+		word.Unknown = true
+	}
+	return word, true
+}
+
+// UnknownWordData fills a word note for a token no dictionary knows yet
+func unknownWordData(word Word) []WordData {
+	return []WordData{{
+		Word:        word.DictForm,
+		Definitions: "(unknown, resolve it from Unknown.md)",
+		Reading:     word.Word,
+	}}
+}
+
+// ResolveCommand records how the user wants an unknown token handled from now on
+func resolveCommand(args []string) {
+	if len(args) < 2 {
+		fmt.Println("Usage: resolve <word> dictionary <dictionary form> | resolve <word> userdic <reading> [pos] | resolve <word> ignore")
+		return
+	}
+	loadUnknowns()
+	surface := args[0]
+	entry, exists := unknownStore[surface]
+	if !exists {
+		entry = UnknownEntry{Surface: surface, Pos: "noun"}
+	}
+
+	switch args[1] {
+	case ResolveIgnore:
+		entry.Resolution = ResolveIgnore
+	case ResolveDictionary:
+		if len(args) < 3 {
+			fmt.Println("Usage: resolve <word> dictionary <dictionary form>")
+			return
+		}
+		entry.Resolution = ResolveDictionary
+		entry.DictForm = args[2]
+	case ResolveUserDic:
+		if len(args) < 3 {
+			fmt.Println("Usage: resolve <word> userdic <reading> [pos]")
+			return
+		}
+		pos := "名詞"
+		if len(args) > 3 {
+			pos = args[3]
+		}
+		if err := writeUserDicEntry(surface, []string{surface}, []string{hiraganaToKatakana(args[2])}, pos); err != nil {
+			fmt.Printf("Error writing to %s: %v\n", userDicPath, err)
+			return
+		}
+		entry.Resolution = ResolveUserDic
+		loadUserDic()
+	default:
+		fmt.Printf("Unknown resolution %s\n", args[1])
+		return
+	}
+
+	unknownStore[surface] = entry
+	saveUnknowns()
+	fmt.Printf("Resolved %s as %s\n", surface, entry.Resolution)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ikawaha/kagome/tokenizer"
+)
+
+var (
+	userDic       tokenizer.UserDic
+	userDicLoaded = false
+)
+
+// LoadUserDic loads the user dictionary from the notes directory, a missing file just means no user dictionary
+func loadUserDic() {
+	userDicLoaded = false
+	if _, err := os.Stat(userDicPath); os.IsNotExist(err) {
+		return
+	}
+	udic, err := tokenizer.NewUserDic(userDicPath)
+	if err != nil {
+		fmt.Printf("Error loading user dictionary %s: %v\n", userDicPath, err)
+		return
+	}
+	userDic = udic
+	userDicLoaded = true
+	tokenizerOnce.Do(buildTokenizer)
+	sharedTokenizer.SetUserDic(userDic)
+}
+
+// UserDicWords turns a user dictionary token into one Word per segment the user gave
+func userDicWords(token tokenizer.Token) []Word {
+	features := token.Features()
+	pos := getEnglishPOS(features[0])
+	if pos == "" {
+		pos = features[0]
+	}
+	var output []Word
+	for _, segment := range strings.Split(features[1], "/") {
+		output = append(output, Word{
+			Pos:      pos,
+			DictForm: segment,
+			Form:     "",
+			Word:     segment,
+		})
+	}
+	return output
+}
+
+// WriteUserDicEntry adds a line to the user dictionary: surface,segmentation,reading,pos
+// kagome refuses duplicate surfaces so an older entry for the same surface is replaced
+func writeUserDicEntry(surface string, tokens, yomi []string, pos string) error {
+	if len(tokens) == 0 || len(tokens) != len(yomi) {
+		return fmt.Errorf("segmentation %v and reading %v must have the same number of parts", tokens, yomi)
+	}
+	var lines []string
+	if _, err := os.Stat(userDicPath); err == nil {
+		existing, err := readLines(userDicPath)
+		if err != nil {
+			return err
+		}
+		for _, line := range existing {
+			if strings.HasPrefix(line, surface+",") {
+				continue
+			}
+			lines = append(lines, line)
+		}
+	}
+	lines = append(lines, fmt.Sprintf("%s,%s,%s,%s", surface, strings.Join(tokens, " "), strings.Join(yomi, " "), pos))
+
+	return os.WriteFile(userDicPath, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+// SegmentationCorrections compares kagome's split of a sentence with the user's and returns each span that differs
+func segmentationCorrections(sentence string, corrected []string) ([][]string, error) {
+	if strings.Join(corrected, "") != sentence {
+		return nil, fmt.Errorf("corrected segmentation %q doesn't spell out %q", strings.Join(corrected, " "), sentence)
+	}
+
+	parsedBounds := map[int]bool{}
+	offset := 0
+	for _, token := range tokenize(sentence) {
+		if token.Class == tokenizer.DUMMY {
+			continue
+		}
+		offset += len(token.Surface)
+		parsedBounds[offset] = true
+	}
+
+	var spans [][]string
+	var span []string
+	offset = 0
+	differs := false
+	for _, c := range corrected {
+		span = append(span, c)
+		offset += len(c)
+		if !parsedBounds[offset] {
+			// kagome runs this token into the next one
+			differs = true
+			continue
+		}
+		start := offset - len(strings.Join(span, ""))
+		for i := start + 1; i < offset; i++ {
+			if parsedBounds[i] {
+				// kagome cuts this span somewhere the user didn't
+				differs = true
+			}
+		}
+		if differs {
+			spans = append(spans, span)
+		}
+		span = nil
+		differs = false
+	}
+	return spans, nil
+}
+
+// CorrectCommand adds user dictionary entries so a sentence is split the way the user split it
+func correctCommand(args []string) {
+	if len(args) < 2 {
+		fmt.Println("Usage: correct <sentence> <corrected segmentation separated by spaces> [pos]")
+		return
+	}
+	pos := "名詞"
+	if len(args) > 2 {
+		pos = args[2]
+	}
+	spans, err := segmentationCorrections(args[0], strings.Fields(args[1]))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if len(spans) == 0 {
+		fmt.Println("The parser already splits this sentence that way")
+		return
+	}
+	for _, span := range spans {
+		var yomi []string
+		for _, s := range span {
+			yomi = append(yomi, hiraganaToKatakana(tokenizerReading(s)))
+		}
+		surface := strings.Join(span, "")
+		if err := writeUserDicEntry(surface, span, yomi, pos); err != nil {
+			fmt.Printf("Error writing to %s: %v\n", userDicPath, err)
+			return
+		}
+		fmt.Printf("Added %s => %s\n", surface, strings.Join(span, " "))
+	}
+	loadUserDic()
+}